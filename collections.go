@@ -0,0 +1,130 @@
+package dotenv
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/fatih/structtag"
+)
+
+const (
+	// defaultDelimiter is the separator used for slices and map entries when
+	// no `delimiter` tag is given.
+	defaultDelimiter = ","
+
+	// defaultKVSeparator is the separator used between keys and values of map
+	// entries when no `kvSeparator` tag is given.
+	defaultKVSeparator = ":"
+)
+
+// delimiterFor returns the delimiter defined by the delimiter tag, or the
+// default one.
+func delimiterFor(tags *structtag.Tags, o *options) string {
+	delimiterTag, err := tags.Get(o.tagNames.Delimiter)
+	if err != nil || delimiterTag.Name == "" {
+		return defaultDelimiter
+	}
+
+	return delimiterTag.Name
+}
+
+// sliceForType splits the given raw value and converts each element into the
+// element type of the given slice or array type.
+func sliceForType(typ reflect.Type, v value, tags *structtag.Tags, varName string, o *options) (interface{}, error) {
+	parts := v.AsStringSlice(delimiterFor(tags, o))
+	out := reflect.New(typ).Elem()
+
+	if typ.Kind() == reflect.Slice {
+		out = reflect.MakeSlice(typ, len(parts), len(parts))
+	}
+
+	if typ.Kind() == reflect.Array && len(parts) > typ.Len() {
+		if o.lenient {
+			return out.Interface(), nil
+		}
+
+		return nil, &InvalidValueError{
+			Var:   varName,
+			Type:  typ.String(),
+			Value: o.mask(string(v)),
+			Err:   fmt.Errorf("got %d elements, at most %d expected", len(parts), typ.Len()),
+		}
+	}
+
+	for i := range parts {
+		elem, err := valueForType(typ.Elem(), value(parts[i]), tags, varName, o)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+
+		out.Index(i).Set(reflect.ValueOf(elem).Convert(typ.Elem()))
+	}
+
+	return out.Interface(), nil
+}
+
+// kvSeparatorFor returns the separator defined by the key/value separator tag, or
+// the default one.
+func kvSeparatorFor(tags *structtag.Tags, o *options) string {
+	separatorTag, err := tags.Get(o.tagNames.KVSeparator)
+	if err != nil || separatorTag.Name == "" {
+		return defaultKVSeparator
+	}
+
+	return separatorTag.Name
+}
+
+// mapForType splits the given raw value into key/value pairs and converts
+// each key and value into the key and element types of the given map type.
+// Malformed pairs and duplicated keys yield an empty map on lenient
+// conversion, as arrays with too many elements do.
+func mapForType(typ reflect.Type, v value, tags *structtag.Tags, varName string, o *options) (interface{}, error) {
+	pairs := v.AsStringSlice(delimiterFor(tags, o))
+	separator := kvSeparatorFor(tags, o)
+	out := reflect.MakeMapWithSize(typ, len(pairs))
+
+	for i := range pairs {
+		kv := strings.SplitN(pairs[i], separator, 2)
+		if len(kv) != 2 {
+			if o.lenient {
+				return reflect.Zero(typ).Interface(), nil
+			}
+
+			return nil, &InvalidValueError{
+				Var:   varName,
+				Type:  typ.String(),
+				Value: o.mask(string(v)),
+				Err:   fmt.Errorf("entry %d `%s` is not a `key%svalue` pair", i, o.mask(pairs[i]), separator),
+			}
+		}
+
+		key, err := valueForType(typ.Key(), value(kv[0]), tags, varName, o)
+		if err != nil {
+			return nil, fmt.Errorf("key `%s`: %w", o.mask(kv[0]), err)
+		}
+
+		elem, err := valueForType(typ.Elem(), value(kv[1]), tags, varName, o)
+		if err != nil {
+			return nil, fmt.Errorf("value of key `%s`: %w", o.mask(kv[0]), err)
+		}
+
+		keyValue := reflect.ValueOf(key).Convert(typ.Key())
+		if out.MapIndex(keyValue).IsValid() {
+			if o.lenient {
+				return reflect.Zero(typ).Interface(), nil
+			}
+
+			return nil, &InvalidValueError{
+				Var:   varName,
+				Type:  typ.String(),
+				Value: o.mask(string(v)),
+				Err:   fmt.Errorf("duplicate key `%s`", o.mask(kv[0])),
+			}
+		}
+
+		out.SetMapIndex(keyValue, reflect.ValueOf(elem).Convert(typ.Elem()))
+	}
+
+	return out.Interface(), nil
+}
@@ -0,0 +1,137 @@
+package dotenv_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tangelo-labs/go-dotenv"
+)
+
+func TestParse_Slices(t *testing.T) {
+	t.Run("GIVEN a struct with slices and arrays of several types AND variables defined for such fields", func(t *testing.T) {
+		t.Setenv("TEST_SLICE_INTS", "1,2,3")
+		t.Setenv("TEST_SLICE_DURATIONS", "1s;2m;1h")
+		t.Setenv("TEST_SLICE_FLOATS", "1.5,2.5")
+		t.Setenv("TEST_SLICE_BOOLS", "true,false")
+		t.Setenv("TEST_SLICE_IPS", "10.0.0.1,10.0.0.2")
+		t.Setenv("TEST_SLICE_COLORS", "red,blue")
+		t.Setenv("TEST_SLICE_ARRAY", "7 8")
+		t.Setenv("TEST_SLICE_BYTES", "a,b")
+		t.Setenv("TEST_SLICE_OCTETS", "1,2,255")
+
+		t.Run("WHEN parsing THEN every element is converted", func(t *testing.T) {
+			env := dummySlices{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, []int{1, 2, 3}, env.Ints)
+			require.Equal(t, []time.Duration{time.Second, 2 * time.Minute, time.Hour}, env.Durations)
+			require.Equal(t, []float64{1.5, 2.5}, env.Floats)
+			require.Equal(t, []bool{true, false}, env.Bools)
+			require.Equal(t, []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2")}, env.IPs)
+			require.Equal(t, []color{colorRed, colorBlue}, env.Colors)
+			require.Equal(t, [3]uint8{7, 8, 0}, env.Array)
+			require.Equal(t, []byte("a,b"), env.Bytes)
+			require.Equal(t, []dummyOctet{1, 2, 255}, env.Octets)
+		})
+	})
+
+	t.Run("GIVEN a struct with an int slice AND a variable with an invalid element", func(t *testing.T) {
+		t.Setenv("TEST_SLICE_INTS", "1,x,3")
+
+		t.Run("WHEN parsing THEN the error reports the element index", func(t *testing.T) {
+			env := dummySlices{}
+			err := dotenv.Parse(&env)
+
+			require.ErrorIs(t, err, dotenv.ErrInvalidValue)
+			require.Contains(t, err.Error(), "element 1")
+		})
+	})
+
+	t.Run("GIVEN a struct with an array AND a variable with too many elements", func(t *testing.T) {
+		t.Setenv("TEST_SLICE_ARRAY", "1 2 3 4")
+
+		t.Run("WHEN parsing THEN an invalid value error is raised", func(t *testing.T) {
+			env := dummySlices{}
+
+			require.ErrorIs(t, dotenv.Parse(&env), dotenv.ErrInvalidValue)
+		})
+	})
+}
+
+type dummySlices struct {
+	Ints      []int           `env:"TEST_SLICE_INTS"`
+	Durations []time.Duration `env:"TEST_SLICE_DURATIONS" delimiter:";"`
+	Floats    []float64       `env:"TEST_SLICE_FLOATS"`
+	Bools     []bool          `env:"TEST_SLICE_BOOLS"`
+	IPs       []net.IP        `env:"TEST_SLICE_IPS"`
+	Colors    []color         `env:"TEST_SLICE_COLORS"`
+	Array     [3]uint8        `env:"TEST_SLICE_ARRAY" delimiter:" "`
+	Bytes     []byte          `env:"TEST_SLICE_BYTES"`
+	Octets    []dummyOctet    `env:"TEST_SLICE_OCTETS"`
+}
+
+type dummyOctet uint8
+
+func TestParse_Maps(t *testing.T) {
+	t.Run("GIVEN a struct with maps AND variables defined for such fields", func(t *testing.T) {
+		t.Setenv("TEST_MAP_LIMITS", "tenantA:10,tenantB:20")
+		t.Setenv("TEST_MAP_FLAGS", "beta=true;dark=false")
+		t.Setenv("TEST_MAP_TIMEOUTS", "1:1s,2:1m")
+
+		t.Run("WHEN parsing THEN keys and values are converted", func(t *testing.T) {
+			env := dummyMaps{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, map[string]int{"tenantA": 10, "tenantB": 20}, env.Limits)
+			require.Equal(t, map[string]bool{"beta": true, "dark": false}, env.Flags)
+			require.Equal(t, map[int]time.Duration{1: time.Second, 2: time.Minute}, env.Timeouts)
+		})
+	})
+
+	t.Run("GIVEN a struct with maps AND no variable defined", func(t *testing.T) {
+		t.Setenv("TEST_MAP_LIMITS", "")
+
+		t.Run("WHEN parsing THEN an empty map is written", func(t *testing.T) {
+			env := dummyMaps{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Empty(t, env.Limits)
+		})
+	})
+
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{name: "a malformed pair", raw: "tenantA:10,tenantB"},
+		{name: "a duplicated key", raw: "tenantA:10,tenantA:20"},
+		{name: "an invalid value", raw: "tenantA:ten"},
+	}
+
+	for _, test := range tests {
+		t.Run("GIVEN a map variable with "+test.name, func(t *testing.T) {
+			t.Setenv("TEST_MAP_LIMITS", test.raw)
+
+			t.Run("WHEN parsing THEN an invalid value error is raised", func(t *testing.T) {
+				env := dummyMaps{}
+
+				require.ErrorIs(t, dotenv.Parse(&env), dotenv.ErrInvalidValue)
+			})
+
+			t.Run("WHEN parsing with lenient conversion THEN no error is raised", func(t *testing.T) {
+				env := dummyMaps{}
+
+				require.NoError(t, dotenv.Parse(&env, dotenv.WithLenientConversion()))
+				require.NotContains(t, env.Limits, "tenantB")
+			})
+		})
+	}
+}
+
+type dummyMaps struct {
+	Limits   map[string]int        `env:"TEST_MAP_LIMITS"`
+	Flags    map[string]bool       `env:"TEST_MAP_FLAGS" delimiter:";" kvSeparator:"="`
+	Timeouts map[int]time.Duration `env:"TEST_MAP_TIMEOUTS"`
+}
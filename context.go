@@ -0,0 +1,93 @@
+package dotenv
+
+import "context"
+
+// overridesKey is the context key under which overrides are stored.
+type overridesKey struct{}
+
+// ContextWithOverrides returns a copy of the given context holding the given
+// overridden environment variables, which are honoured by ParseContext.
+//
+// Unlike WithOverride, such overrides travel along with the context, so they
+// are seen by any goroutine the context is handed to. Overrides already held
+// by the given context are preserved, unless overridden again.
+//
+// This function will panic if the number of arguments is not even.
+//
+// Typical Usage Example:
+//
+//	ctx = dotenv.ContextWithOverrides(ctx, "FOO", "bar")
+//
+//	g, ctx := errgroup.WithContext(ctx)
+//	g.Go(func() error {
+//		return dotenv.ParseContext(ctx, &cfg)
+//	})
+func ContextWithOverrides(ctx context.Context, kv ...string) context.Context {
+	if len(kv)%2 != 0 {
+		panic("dotenv.ContextWithOverrides requires an even number of arguments")
+	}
+
+	parent := overridesFromContext(ctx)
+	tuples := make(map[string]string, len(parent)+len(kv)/2)
+
+	for k, v := range parent {
+		tuples[k] = v
+	}
+
+	for i := 0; i < len(kv); i += 2 {
+		tuples[kv[i]] = kv[i+1]
+	}
+
+	return context.WithValue(ctx, overridesKey{}, tuples)
+}
+
+// ParseContext injects environment variables into the given struct as Parse
+// does, but honouring the overrides held by the given context, which take
+// precedence over any configured source.
+//
+// OverrideSource is left out of the chain of sources, so resolving a variable
+// costs a map lookup instead of a stack inspection, and overrides set by
+// WithOverride or OverrideT are not honoured. Use ContextWithOverrides
+// instead.
+//
+// See Parse and ContextWithOverrides functions for more information.
+func ParseContext(ctx context.Context, st interface{}, opts ...Option) error {
+	return defaultParser.With(opts...).ParseContext(ctx, st)
+}
+
+// ParseContext injects environment variables into the given struct honouring
+// the overrides held by the given context. See ParseContext function for more
+// information.
+func (p *Parser) ParseContext(ctx context.Context, st interface{}) error {
+	c := p.With()
+	c.opts.ctxOverrides = overridesFromContext(ctx)
+	c.opts.sources = withoutOverrideSource(c.opts.sources, c.opts.defaultSources())
+
+	return c.Parse(st)
+}
+
+// withoutOverrideSource returns the given chain of sources, or the given
+// default one if nil, without any OverrideSource.
+func withoutOverrideSource(sources, defaults []Source) []Source {
+	if sources == nil {
+		sources = defaults
+	}
+
+	out := make([]Source, 0, len(sources))
+
+	for _, s := range sources {
+		if _, ok := s.(overrideSource); !ok {
+			out = append(out, s)
+		}
+	}
+
+	return out
+}
+
+// overridesFromContext returns the overrides held by the given context, if
+// any.
+func overridesFromContext(ctx context.Context) map[string]string {
+	tuples, _ := ctx.Value(overridesKey{}).(map[string]string)
+
+	return tuples
+}
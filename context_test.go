@@ -0,0 +1,96 @@
+package dotenv_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tangelo-labs/go-dotenv"
+)
+
+func TestParseContext(t *testing.T) {
+	t.Run("GIVEN a context with overrides AND a variable defined in the process environment", func(t *testing.T) {
+		t.Setenv("TEST_CTX_A", "process")
+		t.Setenv("TEST_CTX_B", "process")
+
+		ctx := dotenv.ContextWithOverrides(context.Background(), "TEST_CTX_A", "outer", "TEST_CTX_B", "outer")
+
+		t.Run("WHEN parsing with such context THEN overridden values are seen", func(t *testing.T) {
+			env := dummyContext{}
+
+			require.NoError(t, dotenv.ParseContext(ctx, &env))
+			require.Equal(t, "outer", env.A)
+			require.Equal(t, "outer", env.B)
+		})
+
+		t.Run("WHEN deriving a context with more overrides THEN overrides are merged AND the innermost wins", func(t *testing.T) {
+			env := dummyContext{}
+			inner := dotenv.ContextWithOverrides(ctx, "TEST_CTX_B", "inner")
+
+			require.NoError(t, dotenv.ParseContext(inner, &env))
+			require.Equal(t, "outer", env.A)
+			require.Equal(t, "inner", env.B)
+		})
+
+		t.Run("WHEN parsing without such context THEN the process environment is seen", func(t *testing.T) {
+			env := dummyContext{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, "process", env.A)
+		})
+
+		t.Run("WHEN parsing from goroutines spawned with such context THEN overridden values are seen", func(t *testing.T) {
+			wg := sync.WaitGroup{}
+
+			for i := 0; i < 50; i++ {
+				wg.Add(1)
+
+				go func(idx int) {
+					defer wg.Done()
+
+					env := dummyContext{}
+					value := fmt.Sprintf("goroutine-%d", idx)
+
+					if err := dotenv.ParseContext(dotenv.ContextWithOverrides(ctx, "TEST_CTX_B", value), &env); err != nil {
+						t.Errorf("failed to parse: %s", err)
+					}
+
+					if env.A != "outer" || env.B != value {
+						t.Errorf("expected outer/%s, got %s/%s", value, env.A, env.B)
+					}
+				}(i)
+			}
+
+			wg.Wait()
+		})
+	})
+
+	t.Run("GIVEN a variable overridden using WithOverride", func(t *testing.T) {
+		t.Setenv("TEST_CTX_A", "process")
+
+		t.Run("WHEN parsing with a context within such call THEN the stack based override is not seen", func(t *testing.T) {
+			env := dummyContext{}
+
+			dotenv.WithOverride(func() {
+				require.NoError(t, dotenv.ParseContext(context.Background(), &env))
+			}, "TEST_CTX_A", "stack")
+
+			require.Equal(t, "process", env.A)
+		})
+	})
+
+	t.Run("GIVEN an odd number of override arguments", func(t *testing.T) {
+		t.Run("WHEN building a context THEN it panics", func(t *testing.T) {
+			require.Panics(t, func() {
+				dotenv.ContextWithOverrides(context.Background(), "TEST_CTX_A")
+			})
+		})
+	})
+}
+
+type dummyContext struct {
+	A string `env:"TEST_CTX_A"`
+	B string `env:"TEST_CTX_B"`
+}
@@ -0,0 +1,73 @@
+package dotenv
+
+import (
+	"encoding"
+	"reflect"
+)
+
+// Decoder is implemented by types that can decode themselves from the raw
+// value of an environment variable.
+//
+// Parse honours this interface on both value and pointer receivers. It takes
+// precedence over any other decoding interface implemented by the type.
+type Decoder interface {
+	DecodeEnv(raw string) error
+}
+
+// setter is implemented by flag.Value compatible types.
+type setter interface {
+	Set(raw string) error
+}
+
+// decodeFunc returns a function which decodes a raw value into the given
+// target pointer using the first decoding interface it implements, in the
+// following order: Decoder, encoding.TextUnmarshaler,
+// encoding.BinaryUnmarshaler and flag.Value-style setters.
+//
+// It returns nil if the target implements none of them.
+func decodeFunc(target interface{}) func(raw string) error {
+	switch t := target.(type) {
+	case Decoder:
+		return t.DecodeEnv
+	case encoding.TextUnmarshaler:
+		return func(raw string) error {
+			return t.UnmarshalText([]byte(raw))
+		}
+	case encoding.BinaryUnmarshaler:
+		return func(raw string) error {
+			return t.UnmarshalBinary([]byte(raw))
+		}
+	case setter:
+		return t.Set
+	}
+
+	return nil
+}
+
+// decoderFor returns a conversion function for the given type if it, or a
+// pointer to it, implements any of the supported decoding interfaces.
+//
+// Pointer types are allocated, so both `net.IP` and `*regexp.Regexp` fields
+// are supported.
+func decoderFor(typ reflect.Type, v value) (func() (interface{}, error), bool) {
+	target := reflect.New(typ)
+	result := target.Elem()
+
+	if typ.Kind() == reflect.Ptr {
+		target = reflect.New(typ.Elem())
+		result = target
+	}
+
+	decode := decodeFunc(target.Interface())
+	if decode == nil {
+		return nil, false
+	}
+
+	return func() (interface{}, error) {
+		if err := decode(string(v)); err != nil {
+			return nil, err
+		}
+
+		return result.Interface(), nil
+	}, true
+}
@@ -0,0 +1,102 @@
+package dotenv_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tangelo-labs/go-dotenv"
+)
+
+func TestParse_Decoders(t *testing.T) {
+	t.Run("GIVEN a struct with fields implementing decoding interfaces AND variables defined for such fields", func(t *testing.T) {
+		t.Setenv("TEST_DECODER_IP", "10.0.0.1")
+		t.Setenv("TEST_DECODER_URL", "https://example.com/path")
+		t.Setenv("TEST_DECODER_REGEXP", "^foo-[0-9]+$")
+		t.Setenv("TEST_DECODER_COLOR", "green")
+		t.Setenv("TEST_DECODER_COLOR_PTR", "blue")
+		t.Setenv("TEST_DECODER_LIST", "a|b")
+		t.Setenv("TEST_DECODER_MODE", "debug")
+
+		t.Run("WHEN parsing THEN fields are decoded", func(t *testing.T) {
+			env := dummyDecoders{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, net.ParseIP("10.0.0.1"), env.IP)
+			require.Equal(t, "example.com", env.URL.Host)
+			require.NotNil(t, env.Regexp)
+			require.True(t, env.Regexp.MatchString("foo-42"))
+			require.Equal(t, colorGreen, env.Color)
+			require.NotNil(t, env.ColorPtr)
+			require.Equal(t, colorBlue, *env.ColorPtr)
+			require.Equal(t, setterList{"a", "b"}, env.List)
+			require.Equal(t, dummyMode("debug"), env.Mode)
+		})
+	})
+
+	t.Run("GIVEN a struct with a decoder field AND an invalid value", func(t *testing.T) {
+		t.Setenv("TEST_DECODER_COLOR", "purple")
+
+		t.Run("WHEN parsing THEN an invalid value error wrapping the decoder error is raised", func(t *testing.T) {
+			env := dummyDecoders{}
+			err := dotenv.Parse(&env)
+
+			require.ErrorIs(t, err, dotenv.ErrInvalidValue)
+			require.ErrorIs(t, err, errUnknownColor)
+		})
+	})
+}
+
+var errUnknownColor = errors.New("unknown color")
+
+type color int
+
+const (
+	colorRed color = iota
+	colorGreen
+	colorBlue
+)
+
+func (c *color) DecodeEnv(raw string) error {
+	switch raw {
+	case "red":
+		*c = colorRed
+	case "green":
+		*c = colorGreen
+	case "blue":
+		*c = colorBlue
+	default:
+		return fmt.Errorf("%w: %s", errUnknownColor, raw)
+	}
+
+	return nil
+}
+
+type setterList []string
+
+func (l *setterList) String() string {
+	return strings.Join(*l, "|")
+}
+
+func (l *setterList) Set(raw string) error {
+	*l = strings.Split(raw, "|")
+
+	return nil
+}
+
+type dummyMode string
+
+type dummyDecoders struct {
+	IP       net.IP         `env:"TEST_DECODER_IP"`
+	URL      url.URL        `env:"TEST_DECODER_URL"`
+	Regexp   *regexp.Regexp `env:"TEST_DECODER_REGEXP"`
+	Color    color          `env:"TEST_DECODER_COLOR"`
+	ColorPtr *color         `env:"TEST_DECODER_COLOR_PTR"`
+	List     setterList     `env:"TEST_DECODER_LIST"`
+	Mode     dummyMode      `env:"TEST_DECODER_MODE"`
+}
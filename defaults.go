@@ -0,0 +1,93 @@
+package dotenv
+
+import (
+	"reflect"
+
+	"github.com/fatih/structtag"
+)
+
+// Defaulter is implemented by structs computing default values which cannot
+// be expressed using the `default` tag, such as values derived from other
+// fields.
+//
+// Parse calls SetDefaults on the given struct, and on every nested struct,
+// once all of their fields have been injected and before any validation
+// takes place. Nested structs are visited first. The method of an embedded
+// struct is promoted to the embedding one, so it is only called once,
+// through the embedding struct; an embedding struct declaring its own
+// SetDefaults method is responsible for calling the embedded one.
+type Defaulter interface {
+	SetDefaults()
+}
+
+// collectDefaults records the raw default value of every tagged field of the
+// given struct type, and of its nested struct types, indexed by variable name.
+func collectDefaults(typ reflect.Type, prefix string, o *options, defaults map[string]string, visiting map[reflect.Type]bool) {
+	if visiting[typ] {
+		return
+	}
+
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for idx := 0; idx < typ.NumField(); idx++ {
+		sf := typ.Field(idx)
+
+		tags, err := structtag.Parse(string(sf.Tag))
+		if err != nil {
+			continue
+		}
+
+		if envTag, eErr := tags.Get(o.tagNames.Env); eErr == nil {
+			if defaultTag, dErr := tags.Get(o.tagNames.Default); dErr == nil {
+				defaults[prefix+envTag.Name] = defaultTag.Value()
+			}
+
+			continue
+		}
+
+		nested, ok := nestedStructType(sf)
+		if !ok {
+			continue
+		}
+
+		childPrefix := prefix
+		if prefixTag, pErr := tags.Get(o.tagNames.Prefix); pErr == nil {
+			childPrefix += prefixTag.Name
+		}
+
+		collectDefaults(nested, childPrefix, o, defaults, visiting)
+	}
+}
+
+// defaulterType is the type of the Defaulter interface.
+var defaulterType = reflect.TypeOf((*Defaulter)(nil)).Elem()
+
+// callDefaulter calls SetDefaults on the given struct value if it implements
+// the Defaulter interface, unless the method is promoted to the given struct
+// type embedding it.
+func callDefaulter(val reflect.Value, parent reflect.Type) {
+	if !val.CanAddr() || !val.Addr().CanInterface() {
+		return
+	}
+
+	if parent != nil && reflect.PointerTo(parent).Implements(defaulterType) {
+		return
+	}
+
+	if d, ok := val.Addr().Interface().(Defaulter); ok {
+		d.SetDefaults()
+	}
+}
+
+// lookupWithDefaults resolves the given variable through the configured
+// sources, falling back to the default value of the field bound to it.
+func (o *options) lookupWithDefaults(key string) (string, bool) {
+	if v, ok := o.lookup(key); ok {
+		return v, true
+	}
+
+	v, ok := o.defaults[key]
+
+	return v, ok
+}
@@ -0,0 +1,188 @@
+package dotenv
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/structtag"
+)
+
+// Origin tells where the value of a struct field comes from.
+type Origin string
+
+const (
+	// OriginEnv is the origin of values defined in the process environment.
+	OriginEnv Origin = "env"
+
+	// OriginFile is the origin of values read from dotenv files, by Load or
+	// FileSource, from files mounted in a directory read by DirSource, or from
+	// files pointed to by `_FILE` variables.
+	OriginFile Origin = "file"
+
+	// OriginDefault is the origin of values given by `default` tags or set by
+	// Defaulter implementations.
+	OriginDefault Origin = "default"
+
+	// OriginOverride is the origin of values given by WithOverride, OverrideT
+	// or ContextWithOverrides.
+	OriginOverride Origin = "override"
+
+	// OriginSource is the origin of values defined by any other Source given
+	// to WithSources, such as MapSource.
+	OriginSource Origin = "source"
+
+	// OriginUnset is the origin of fields whose variable is not defined and
+	// that have no default value.
+	OriginUnset Origin = "unset"
+)
+
+// FieldDescription describes the value a single struct field was resolved to.
+type FieldDescription struct {
+	// Field is the path of the struct field, such as `DB.Port`.
+	Field string `json:"field"`
+
+	// Var is the name of the environment variable bound to the field.
+	Var string `json:"var"`
+
+	// Type is the name of the type of the field.
+	Type string `json:"type"`
+
+	// Value is the current value of the field, masked for secrets.
+	Value string `json:"value"`
+
+	// Origin tells where the value comes from.
+	Origin Origin `json:"origin"`
+}
+
+// Description is the list of every field bound to an environment variable
+// within a struct, in declaration order. It can be printed as a table, and
+// marshaled to JSON or logged with log/slog as is.
+type Description []FieldDescription
+
+// Describe describes the values of the given, already parsed, struct, so that
+// the effective configuration can be logged on startup:
+//
+//	if err := dotenv.LoadAndParse(&cfg); err != nil {
+//		panic(err)
+//	}
+//
+//	desc, err := dotenv.Describe(&cfg)
+//	if err != nil {
+//		panic(err)
+//	}
+//
+//	slog.Info("configuration loaded", desc.Group("config"))
+//
+// Origins are found by resolving each variable again through the same chain
+// of sources used by Parse, so the same options must be given to both. The
+// values of Secret fields and of fields with the `secret` env option are
+// masked. The given struct is never modified: fields of structs behind nil
+// pointers are described as unset.
+func Describe(st interface{}, opts ...Option) (Description, error) {
+	return defaultParser.With(opts...).Describe(st)
+}
+
+// Describe describes the values of the given, already parsed, struct. See
+// Describe function for more information.
+func (p *Parser) Describe(st interface{}) (Description, error) {
+	o := p.opts
+	o.readOnly = true
+
+	val := reflect.ValueOf(st)
+	if val.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("%w: given `%s` is not a pointer", ErrNotAPointer, val.Kind())
+	}
+
+	val = val.Elem()
+
+	if val.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: given `%s` is not a pointer", ErrNotAPointer, val.Kind())
+	}
+
+	var desc Description
+
+	walkStruct(val, "", "", &o,
+		func(field reflect.Value, path, varName string, envOptions []string, tags *structtag.Tags) *FieldError {
+			desc = append(desc, FieldDescription{
+				Field:  path,
+				Var:    varName,
+				Type:   field.Type().String(),
+				Value:  describeValue(field, isSecret(field.Type(), envOptions)),
+				Origin: provenanceOf(field, varName, tags, &o).Origin,
+			})
+
+			return nil
+		},
+		func(reflect.Value, string, reflect.Type) *FieldError {
+			return nil
+		},
+	)
+
+	return desc, nil
+}
+
+// String renders the description as a text table.
+func (d Description) String() string {
+	b := &strings.Builder{}
+	w := tabwriter.NewWriter(b, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(w, "FIELD\tVAR\tTYPE\tVALUE\tORIGIN")
+
+	for _, f := range d {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", f.Field, f.Var, f.Type, f.Value, f.Origin)
+	}
+
+	_ = w.Flush()
+
+	return b.String()
+}
+
+// LogValue implements slog.LogValuer, rendering the description as a group
+// holding a group per field, keyed by field path.
+func (d Description) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, len(d))
+
+	for _, f := range d {
+		attrs = append(attrs, slog.Group(f.Field,
+			slog.String("var", f.Var),
+			slog.String("type", f.Type),
+			slog.String("value", f.Value),
+			slog.String("origin", string(f.Origin)),
+		))
+	}
+
+	return slog.GroupValue(attrs...)
+}
+
+// Group returns the description as a slog group attribute with the given key.
+func (d Description) Group(key string) slog.Attr {
+	return slog.Attr{Key: key, Value: d.LogValue()}
+}
+
+// describeValue renders the value of the given field, masking secrets.
+func describeValue(field reflect.Value, secret bool) string {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return ""
+		}
+
+		field = field.Elem()
+	}
+
+	if field.IsZero() {
+		return ""
+	}
+
+	if secret {
+		return redacted
+	}
+
+	if isByteSlice(field.Type()) {
+		return string(field.Bytes())
+	}
+
+	return fmt.Sprint(field)
+}
@@ -0,0 +1,135 @@
+package dotenv_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tangelo-labs/go-dotenv"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Run("GIVEN variables defined in the environment, a dotenv file and an override", func(t *testing.T) {
+		dir := writeTemp(t, map[string]string{
+			".env": "TEST_DESCRIBE_PORT=8080\nTEST_DESCRIBE_PASSWORD=s3cr3t\n",
+		})
+
+		t.Setenv("TEST_DESCRIBE_HOST", "db.local")
+		dotenv.OverrideT(t, "TEST_DESCRIBE_LEVEL", "debug")
+		unsetAfter(t, "TEST_DESCRIBE_PORT", "TEST_DESCRIBE_PASSWORD")
+
+		require.NoError(t, dotenv.LoadWithOptions(dotenv.WithSearchDir(dir), dotenv.WithFiles(".env")))
+
+		var cfg dummyDescribe
+
+		require.NoError(t, dotenv.Parse(&cfg))
+
+		desc, err := dotenv.Describe(&cfg)
+		require.NoError(t, err)
+
+		t.Run("WHEN describing the parsed struct THEN every field is listed in order with its origin", func(t *testing.T) {
+			require.Equal(t, dotenv.Description{
+				{Field: "Host", Var: "TEST_DESCRIBE_HOST", Type: "string", Value: "db.local", Origin: dotenv.OriginEnv},
+				{Field: "Port", Var: "TEST_DESCRIBE_PORT", Type: "int", Value: "8080", Origin: dotenv.OriginFile},
+				{Field: "Password", Var: "TEST_DESCRIBE_PASSWORD", Type: "dotenv.Secret", Value: "******", Origin: dotenv.OriginFile},
+				{Field: "Log.Level", Var: "TEST_DESCRIBE_LEVEL", Type: "string", Value: "debug", Origin: dotenv.OriginOverride},
+				{Field: "Log.Format", Var: "TEST_DESCRIBE_FORMAT", Type: "string", Value: "json", Origin: dotenv.OriginDefault},
+				{Field: "Token", Var: "TEST_DESCRIBE_TOKEN", Type: "string", Value: "", Origin: dotenv.OriginUnset},
+			}, desc)
+		})
+
+		t.Run("WHEN rendering the description as text THEN a table without secrets is returned", func(t *testing.T) {
+			out := desc.String()
+
+			require.Contains(t, out, "FIELD")
+			require.Regexp(t, `Log\.Level\s+TEST_DESCRIBE_LEVEL\s+string\s+debug\s+override`, out)
+			require.NotContains(t, out, "s3cr3t")
+		})
+
+		t.Run("WHEN rendering the description as JSON THEN a list of fields is returned", func(t *testing.T) {
+			out, err := json.Marshal(desc)
+			require.NoError(t, err)
+
+			var fields []map[string]string
+
+			require.NoError(t, json.Unmarshal(out, &fields))
+			require.Len(t, fields, 6)
+			require.Equal(t, map[string]string{
+				"field":  "Port",
+				"var":    "TEST_DESCRIBE_PORT",
+				"type":   "int",
+				"value":  "8080",
+				"origin": "file",
+			}, fields[1])
+		})
+
+		t.Run("WHEN logging the description THEN a group per field is logged", func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			slog.New(slog.NewTextHandler(buf, nil)).Info("boot", desc.Group("config"))
+
+			require.Contains(t, buf.String(), "config.Host.value=db.local config.Host.origin=env")
+			require.Contains(t, buf.String(), "config.Password.value=******")
+			require.NotContains(t, buf.String(), "s3cr3t")
+		})
+	})
+
+	t.Run("GIVEN variables resolved through custom sources", func(t *testing.T) {
+		src := dotenv.MapSource(map[string]string{"TEST_DESCRIBE_HOST": "db.local"})
+
+		var cfg struct {
+			Host string `env:"TEST_DESCRIBE_HOST"`
+		}
+
+		require.NoError(t, dotenv.ParseFrom(&cfg, src))
+
+		t.Run("WHEN describing the struct with the same sources THEN the origin is reported as a source", func(t *testing.T) {
+			desc, err := dotenv.Describe(&cfg, dotenv.WithSources(src))
+			require.NoError(t, err)
+
+			require.Equal(t, dotenv.OriginSource, desc[0].Origin)
+		})
+	})
+
+	t.Run("GIVEN a struct with a nil pointer to a struct binding variables", func(t *testing.T) {
+		src := dotenv.MapSource(map[string]string{"TEST_DESCRIBE_CACHE_HOST": "cache.local"})
+
+		t.Run("WHEN describing it THEN the pointer is left nil AND its fields are reported as unset", func(t *testing.T) {
+			cfg := dummyDescribePointer{}
+
+			desc, err := dotenv.Describe(&cfg, dotenv.WithSources(src))
+			require.NoError(t, err)
+
+			require.Nil(t, cfg.Cache)
+			require.Equal(t, dotenv.Description{
+				{Field: "Cache.Host", Var: "TEST_DESCRIBE_CACHE_HOST", Type: "string", Origin: dotenv.OriginUnset},
+			}, desc)
+		})
+	})
+
+	t.Run("GIVEN a value which is not a pointer to a struct", func(t *testing.T) {
+		t.Run("WHEN describing it THEN an error is returned", func(t *testing.T) {
+			_, err := dotenv.Describe(dummyDescribe{})
+
+			require.ErrorIs(t, err, dotenv.ErrNotAPointer)
+		})
+	})
+}
+
+type dummyDescribePointer struct {
+	Cache *struct {
+		Host string `env:"TEST_DESCRIBE_CACHE_HOST"`
+	}
+}
+
+type dummyDescribe struct {
+	Host     string        `env:"TEST_DESCRIBE_HOST"`
+	Port     int           `env:"TEST_DESCRIBE_PORT"`
+	Password dotenv.Secret `env:"TEST_DESCRIBE_PASSWORD"`
+	Log      struct {
+		Level  string `env:"TEST_DESCRIBE_LEVEL"`
+		Format string `env:"TEST_DESCRIBE_FORMAT" default:"json"`
+	}
+	Token string `env:"TEST_DESCRIBE_TOKEN"`
+}
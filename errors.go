@@ -0,0 +1,129 @@
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// InvalidValueError is returned when the raw value of an environment variable
+// cannot be converted to the type of the field it is injected into.
+//
+// It matches ErrInvalidValue when used with errors.Is, and unwraps to the
+// underlying conversion error.
+type InvalidValueError struct {
+	// Var is the name of the environment variable.
+	Var string
+
+	// Type is the name of the target type.
+	Type string
+
+	// Value is the raw value that could not be converted.
+	Value string
+
+	// Err is the underlying conversion error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *InvalidValueError) Error() string {
+	return fmt.Sprintf("%s: environment variable `%s` with value `%s` cannot be converted to `%s`: %s",
+		ErrInvalidValue, e.Var, e.Value, e.Type, e.Err)
+}
+
+// Is reports whether the target is ErrInvalidValue.
+func (e *InvalidValueError) Is(target error) bool {
+	return target == ErrInvalidValue
+}
+
+// Unwrap returns the underlying conversion error.
+func (e *InvalidValueError) Unwrap() error {
+	return e.Err
+}
+
+// FieldError describes the failure to inject a single struct field.
+type FieldError struct {
+	// Field is the path of the struct field, such as `DB.Port`. It is empty
+	// for errors returned by the Validate method of the root struct.
+	Field string
+
+	// Var is the name of the environment variable bound to the field.
+	Var string
+
+	// Provenance tells where the value of the field comes from. It is nil
+	// for errors returned by Validate methods.
+	Provenance *Provenance
+
+	// Err is the reason of the failure.
+	Err error
+}
+
+// Error implements the error interface. The position of the definition of
+// the value is included when it was read from a file.
+func (e *FieldError) Error() string {
+	if e.Field == "" {
+		return e.Err.Error()
+	}
+
+	if e.Provenance != nil && e.Provenance.File != "" {
+		return fmt.Sprintf("field `%s` at %s: %s", e.Field, e.Provenance, e.Err)
+	}
+
+	return fmt.Sprintf("field `%s`: %s", e.Field, e.Err)
+}
+
+// Unwrap returns the reason of the failure.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors holds every field error found during a single Parse call.
+//
+// It matches any of the package error sentinels (ErrRequiredField,
+// ErrEmptyField, ErrInvalidValue, etc.) found in its items when used with
+// errors.Is.
+type ValidationErrors []*FieldError
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	lines := make([]string, 0, len(e))
+
+	for i := range e {
+		lines = append(lines, e[i].Error())
+	}
+
+	return fmt.Sprintf("%d configuration error(s):\n\t%s", len(e), strings.Join(lines, "\n\t"))
+}
+
+// Unwrap returns the list of field errors.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+
+	for i := range e {
+		errs = append(errs, e[i])
+	}
+
+	return errs
+}
+
+// Is reports whether any of the field errors matches the target.
+func (e ValidationErrors) Is(target error) bool {
+	for i := range e {
+		if errors.Is(e[i], target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first field error that matches the target.
+func (e ValidationErrors) As(target interface{}) bool {
+	for i := range e {
+		if errors.As(e[i], target) {
+			return true
+		}
+	}
+
+	return false
+}
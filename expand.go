@@ -0,0 +1,159 @@
+package dotenv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// expander expands variable references found in raw values.
+type expander struct {
+	lookup   func(key string) (string, bool)
+	visiting map[string]bool
+}
+
+// WithExpansion enables the expansion of variable references within the
+// values and defaults of every field. See Parse function for more
+// information.
+func WithExpansion() Option {
+	return func(o *options) {
+		o.expand = true
+	}
+}
+
+// newExpander builds an expander resolving references using the given lookup
+// function.
+func newExpander(lookup func(key string) (string, bool)) *expander {
+	return &expander{
+		lookup:   lookup,
+		visiting: make(map[string]bool),
+	}
+}
+
+// expandVar expands the references found in the given raw value of the given
+// variable.
+func (e *expander) expandVar(name, raw string) (string, error) {
+	e.visiting[name] = true
+	defer delete(e.visiting, name)
+
+	return e.expand(raw)
+}
+
+// expand replaces every `${VAR}`, `${VAR:-fallback}` and `${VAR:?message}`
+// reference found in the given string, and every `$$` by a single `$`.
+func (e *expander) expand(s string) (string, error) {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+
+			continue
+		}
+
+		switch s[i+1] {
+		case '$':
+			b.WriteByte('$')
+			i++
+		case '{':
+			end := closingBrace(s, i+2)
+			if end < 0 {
+				return "", fmt.Errorf("%w: unterminated reference in `%s`", ErrExpansion, s)
+			}
+
+			v, err := e.resolve(s[i+2 : end])
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteString(v)
+			i = end
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String(), nil
+}
+
+// resolve evaluates the given reference expression, without the enclosing
+// `${` and `}`.
+func (e *expander) resolve(expr string) (string, error) {
+	name, op, arg := expr, "", ""
+
+	if i := strings.Index(expr, ":"); i >= 0 && i+1 < len(expr) && (expr[i+1] == '-' || expr[i+1] == '?') {
+		name, op, arg = expr[:i], expr[i:i+2], expr[i+2:]
+	}
+
+	if name == "" {
+		return "", fmt.Errorf("%w: empty variable name in reference `${%s}`", ErrExpansion, expr)
+	}
+
+	v, defined, err := e.variable(name)
+	if err != nil {
+		return "", err
+	}
+
+	if defined && v != "" {
+		return v, nil
+	}
+
+	switch op {
+	case ":-":
+		return e.expand(arg)
+	case ":?":
+		msg, mErr := e.expand(arg)
+		if mErr != nil {
+			return "", mErr
+		}
+
+		if msg == "" {
+			msg = "must be defined"
+		}
+
+		return "", fmt.Errorf("%w: variable `%s` %s", ErrExpansion, name, msg)
+	}
+
+	return v, nil
+}
+
+// variable returns the expanded value of the given variable, and whether it
+// is defined.
+func (e *expander) variable(name string) (string, bool, error) {
+	if e.visiting[name] {
+		return "", false, fmt.Errorf("%w: cyclic reference to variable `%s`", ErrExpansion, name)
+	}
+
+	raw, ok := e.lookup(name)
+	if !ok {
+		return "", false, nil
+	}
+
+	v, err := e.expandVar(name, raw)
+	if err != nil {
+		return "", false, err
+	}
+
+	return v, true, nil
+}
+
+// closingBrace returns the index of the brace closing the reference starting
+// at the given index, taking nested references into account, or -1 if there
+// is none.
+func closingBrace(s string, start int) int {
+	depth := 1
+
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,62 @@
+package dotenv
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpander_Expand(t *testing.T) {
+	vars := map[string]string{
+		"USER":  "admin",
+		"HOST":  "db.local",
+		"EMPTY": "",
+		"URL":   "postgres://${USER}@${HOST}",
+		"SELF":  "${SELF}",
+		"PING":  "${PONG}",
+		"PONG":  "${PING}",
+	}
+
+	lookup := func(key string) (string, bool) {
+		v, ok := vars[key]
+
+		return v, ok
+	}
+
+	tests := []struct {
+		raw      string
+		expected string
+		fails    bool
+	}{
+		{raw: "plain", expected: "plain"},
+		{raw: "${USER}", expected: "admin"},
+		{raw: "${MISSING}", expected: ""},
+		{raw: "${MISSING:-fallback}", expected: "fallback"},
+		{raw: "${EMPTY:-fallback}", expected: "fallback"},
+		{raw: "${MISSING:-${HOST}}", expected: "db.local"},
+		{raw: "${URL}/app", expected: "postgres://admin@db.local/app"},
+		{raw: "$$USER costs $5", expected: "$USER costs $5"},
+		{raw: "$${USER}", expected: "${USER}"},
+		{raw: "${MISSING:?is required}", fails: true},
+		{raw: "${SELF}", fails: true},
+		{raw: "${PING}", fails: true},
+		{raw: "${USER", fails: true},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("GIVEN %s raw value WHEN expanded THEN should return %q", test.raw, test.expected), func(t *testing.T) {
+			got, err := newExpander(lookup).expand(test.raw)
+
+			if test.fails {
+				require.ErrorIs(t, err, ErrExpansion)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
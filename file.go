@@ -0,0 +1,115 @@
+package dotenv
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	// defaultFileSuffix is the suffix of variables holding the path of a file
+	// with the value of another variable.
+	defaultFileSuffix = "_FILE"
+
+	// defaultMaxFileSize is the maximum size, in bytes, of files read as
+	// variable values.
+	defaultMaxFileSize = 1 << 20
+)
+
+// FileError is returned when the value of an environment variable cannot be
+// read from the file it points to.
+//
+// It matches ErrFileValue when used with errors.Is, and unwraps to the
+// underlying error.
+type FileError struct {
+	// Var is the name of the environment variable holding the path.
+	Var string
+
+	// Path is the path of the file.
+	Path string
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *FileError) Error() string {
+	return fmt.Sprintf("%s: environment variable `%s` points to file `%s`: %s", ErrFileValue, e.Var, e.Path, e.Err)
+}
+
+// Is reports whether the target is ErrFileValue.
+func (e *FileError) Is(target error) bool {
+	return target == ErrFileValue
+}
+
+// Unwrap returns the underlying error.
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// WithFileSuffix sets the suffix of variables holding the path of a file with
+// the value of another variable, by default `_FILE`. An empty suffix disables
+// such resolution.
+func WithFileSuffix(suffix string) Option {
+	return func(o *options) {
+		o.fileSuffix = suffix
+	}
+}
+
+// WithMaxFileSize sets the maximum size, in bytes, of files read as variable
+// values, by default 1 MiB. A negative size disables the limit.
+func WithMaxFileSize(size int64) Option {
+	return func(o *options) {
+		o.maxFileSize = size
+	}
+}
+
+// readValueFile reads the value of a variable from the file at the given
+// path, honouring the configured size limit. Trailing line breaks are removed
+// unless trim is false.
+func readValueFile(varName, path string, trim bool, o *options) (string, error) {
+	content, err := readLimited(path, o.maxFileSize)
+	if err != nil {
+		return "", &FileError{Var: varName, Path: path, Err: err}
+	}
+
+	if trim {
+		content = trimLineBreaks(content)
+	}
+
+	return content, nil
+}
+
+// readLimited reads the file at the given path, failing if it is larger than
+// the given size. A negative size disables the limit.
+func readLimited(path string, maxSize int64) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+
+	defer f.Close()
+
+	var r io.Reader = f
+	if maxSize >= 0 {
+		r = io.LimitReader(f, maxSize+1)
+	}
+
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	if maxSize >= 0 && int64(len(content)) > maxSize {
+		return "", fmt.Errorf("file exceeds the maximum size of %d bytes", maxSize)
+	}
+
+	return string(content), nil
+}
+
+// trimLineBreaks removes the trailing line breaks usually added by editors and
+// secret management tools.
+func trimLineBreaks(s string) string {
+	return strings.TrimRight(s, "\r\n")
+}
@@ -0,0 +1,88 @@
+package dotenv_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tangelo-labs/go-dotenv"
+)
+
+func TestParse_Files(t *testing.T) {
+	t.Run("GIVEN secret files mounted in a directory", func(t *testing.T) {
+		dir := writeTemp(t, map[string]string{
+			"db_password": "s3cr3t\n",
+			"tls.key":     "-----BEGIN KEY-----\n",
+			"big":         strings.Repeat("x", 64),
+		})
+
+		t.Run("WHEN a variable is undefined AND its _FILE variable is defined THEN the value is read from the file", func(t *testing.T) {
+			env := dummyFiles{}
+			src := dotenv.MapSource(map[string]string{"DB_PASSWORD_FILE": filepath.Join(dir, "db_password")})
+
+			require.NoError(t, dotenv.ParseFrom(&env, src))
+			require.Equal(t, "s3cr3t", env.DBPassword)
+		})
+
+		t.Run("WHEN both a variable and its _FILE variable are defined THEN the variable wins", func(t *testing.T) {
+			env := dummyFiles{}
+			src := dotenv.MapSource(map[string]string{
+				"DB_PASSWORD":      "plain",
+				"DB_PASSWORD_FILE": filepath.Join(dir, "db_password"),
+			})
+
+			require.NoError(t, dotenv.ParseFrom(&env, src))
+			require.Equal(t, "plain", env.DBPassword)
+		})
+
+		t.Run("WHEN a variable with the file option holds a path THEN the file contents are injected", func(t *testing.T) {
+			env := dummyFiles{}
+			src := dotenv.MapSource(map[string]string{
+				"TLS_KEY":     filepath.Join(dir, "tls.key"),
+				"TLS_KEY_RAW": filepath.Join(dir, "tls.key"),
+			})
+
+			require.NoError(t, dotenv.ParseFrom(&env, src))
+			require.Equal(t, "-----BEGIN KEY-----", env.TLSKey)
+			require.Equal(t, "-----BEGIN KEY-----\n", env.TLSKeyRaw)
+		})
+
+		t.Run("WHEN a file does not exist THEN the error names the variable and the path", func(t *testing.T) {
+			env := dummyFiles{}
+			missing := filepath.Join(dir, "missing")
+			err := dotenv.ParseFrom(&env, dotenv.MapSource(map[string]string{"DB_PASSWORD_FILE": missing}))
+
+			require.ErrorIs(t, err, dotenv.ErrFileValue)
+			require.ErrorIs(t, err, os.ErrNotExist)
+
+			var ferr *dotenv.FileError
+
+			require.ErrorAs(t, err, &ferr)
+			require.Equal(t, "DB_PASSWORD_FILE", ferr.Var)
+			require.Equal(t, missing, ferr.Path)
+		})
+
+		t.Run("WHEN a file exceeds the maximum size THEN an error is raised", func(t *testing.T) {
+			env := dummyFiles{}
+			src := dotenv.MapSource(map[string]string{"TLS_KEY": filepath.Join(dir, "big")})
+
+			require.ErrorIs(t, dotenv.Parse(&env, dotenv.WithSources(src), dotenv.WithMaxFileSize(32)), dotenv.ErrFileValue)
+		})
+
+		t.Run("WHEN the file suffix is disabled THEN _FILE variables are ignored", func(t *testing.T) {
+			env := dummyFiles{}
+			src := dotenv.MapSource(map[string]string{"DB_PASSWORD_FILE": filepath.Join(dir, "db_password")})
+
+			require.NoError(t, dotenv.Parse(&env, dotenv.WithSources(src), dotenv.WithFileSuffix("")))
+			require.Empty(t, env.DBPassword)
+		})
+	})
+}
+
+type dummyFiles struct {
+	DBPassword string `env:"DB_PASSWORD"`
+	TLSKey     string `env:"TLS_KEY,file"`
+	TLSKeyRaw  string `env:"TLS_KEY_RAW,file,noTrim"`
+}
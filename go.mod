@@ -1,8 +1,9 @@
 module github.com/tangelo-labs/go-dotenv
 
-go 1.17
+go 1.21
 
 require (
+	github.com/brianvoe/gofakeit/v6 v6.20.1
 	github.com/fatih/structtag v1.2.0
 	github.com/joho/godotenv v1.5.1
 	github.com/stretchr/testify v1.8.1
@@ -10,7 +11,6 @@ require (
 )
 
 require (
-	github.com/brianvoe/gofakeit/v6 v6.20.1 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
@@ -3,37 +3,399 @@ package dotenv
 import (
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/joho/godotenv"
 )
 
+// LoadMode defines how variables read from dotenv files are combined with the
+// variables defined in the process environment.
+type LoadMode int
+
+const (
+	// LoadModeEnvWins sets variables read from dotenv files in the process
+	// environment, unless they are already defined. This is the default mode
+	// of LoadWithOptions.
+	LoadModeEnvWins LoadMode = iota
+
+	// LoadModeFileWins sets variables read from dotenv files in the process
+	// environment, overwriting any variable already defined. This is the mode
+	// used by Load.
+	LoadModeFileWins
+
+	// LoadModeInMemory keeps variables read from dotenv files in memory
+	// without ever modifying the process environment. Parse will fall back to
+	// such values for variables not defined in the process environment.
+	LoadModeInMemory
+)
+
+const (
+	// environmentPlaceholder is replaced by the environment name in dotenv file
+	// names given to WithFiles.
+	environmentPlaceholder = "{env}"
+
+	// environmentVar is the variable holding the environment name when none is
+	// given using WithEnvironment.
+	environmentVar = "APP_ENV"
+)
+
+// defaultFiles is the cascade of dotenv files read by LoadWithOptions, from
+// lowest to highest precedence.
+var defaultFiles = []string{
+	".env",
+	".env.local",
+	".env." + environmentPlaceholder,
+	".env." + environmentPlaceholder + ".local",
+}
+
+// memoryStore holds variables loaded using the LoadModeInMemory mode, and
+// keeps track of the variables set by Load in any mode.
+type memoryStore struct {
+	mu     sync.RWMutex
+	values map[string]string
+
+	// loaded holds, for every variable set by Load, the value it was set to
+	// and the location of its definition.
+	loaded map[string]loadedVar
+}
+
+// loadedVar describes a variable set by Load.
+type loadedVar struct {
+	value string
+	loc   location
+}
+
+// location is the position of the definition of a variable within a file.
+// The line is zero when unknown, or when the whole file is the value.
+type location struct {
+	path string
+	line int
+}
+
+// memoryEnv is the store of the default Parser, populated by LoadModeInMemory
+// loads.
+var memoryEnv = &memoryStore{
+	values: make(map[string]string),
+	loaded: make(map[string]loadedVar),
+}
+
+// WithLoadMode sets the mode used to combine variables read from dotenv
+// files with the process environment.
+func WithLoadMode(mode LoadMode) Option {
+	return func(o *options) {
+		o.loadMode = mode
+	}
+}
+
+// WithFiles sets the names of the dotenv files to read, from lowest to highest
+// precedence. Names may contain the `{env}` placeholder, which is replaced by
+// the environment name; such files are ignored when no environment is set.
+//
+// By default, the following cascade is used: `.env`, `.env.local`,
+// `.env.{env}`, `.env.{env}.local`.
+func WithFiles(names ...string) Option {
+	return func(o *options) {
+		o.files = names
+	}
+}
+
+// WithEnvironment sets the environment name used to resolve dotenv file names.
+// By default, it is read from the `APP_ENV` variable.
+func WithEnvironment(name string) Option {
+	return func(o *options) {
+		o.environment = &name
+	}
+}
+
+// WithRootMarkers stops the upward search of dotenv files at the first
+// directory containing any of the given files or directories, such as
+// `go.mod` or `.git`.
+func WithRootMarkers(markers ...string) Option {
+	return func(o *options) {
+		o.rootMarkers = markers
+	}
+}
+
+// WithSearchDir sets the directory where the search of dotenv files starts.
+// By default, the current working directory is used.
+func WithSearchDir(dir string) Option {
+	return func(o *options) {
+		o.searchDir = dir
+	}
+}
+
 // Load loads the environment.
+//
+// The closest `.env` file found walking up from the current working directory
+// is read, and its variables are set in the process environment overwriting
+// any existing one. See LoadWithOptions for a non-destructive alternative.
 func Load() error {
-	cwd, err := os.Getwd()
+	return defaultParser.With(legacyLoadOptions()...).Load()
+}
+
+// legacyLoadOptions returns the options reproducing the behavior of Load.
+func legacyLoadOptions() []Option {
+	return []Option{
+		WithLoadMode(LoadModeFileWins),
+		WithFiles(".env"),
+	}
+}
+
+// LoadWithOptions loads the environment from a cascade of dotenv files,
+// combining their variables with the process environment according to the
+// given options.
+//
+// Starting at the current working directory and walking up, the first
+// directory containing any of the files given by WithFiles is selected, and
+// every such file found in it is read. Files later in the list take
+// precedence over earlier ones, so by default `.env.{env}.local` overrides
+// `.env.{env}`, which overrides `.env.local`, which overrides `.env`. The
+// search can be stopped at a repository root using WithRootMarkers.
+//
+// By default, variables already defined in the process environment take
+// precedence over the ones read from the files (LoadModeEnvWins). Use
+// WithLoadMode to select a different behavior.
+func LoadWithOptions(opts ...Option) error {
+	return defaultParser.With(opts...).Load()
+}
+
+// load loads the environment according to the given options.
+func load(o *options) error {
+	dir := o.searchDir
+	if dir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+
+		dir = cwd
+	}
+
+	files := findDotEnvFiles(dir, o.fileNames(), o.rootMarkers)
+	if len(files) == 0 {
+		return nil
+	}
+
+	values, locations, err := readDotEnvFiles(files)
 	if err != nil {
 		return err
 	}
 
-	file := findDotEnv(cwd)
-	if file == "" {
+	return apply(values, locations, o.loadMode, o.memory)
+}
+
+// readDotEnvFiles reads the given dotenv files, later files taking precedence
+// over earlier ones, and returns the variables found along with the location
+// of their definition.
+func readDotEnvFiles(paths []string) (map[string]string, map[string]location, error) {
+	values := make(map[string]string)
+	locations := make(map[string]location)
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		vars, err := godotenv.UnmarshalBytes(content)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		lines := definitionLines(content)
+
+		for k, v := range vars {
+			values[k] = v
+			locations[k] = location{path: path, line: lines[k]}
+		}
+	}
+
+	return values, locations, nil
+}
+
+// definitionLines returns the number of the line where each variable of the
+// given dotenv file contents is defined. When a variable is defined several
+// times, the last definition wins, as it does when parsing the file.
+func definitionLines(content []byte) map[string]int {
+	lines := make(map[string]int)
+
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		end := strings.IndexAny(line, "=:")
+		if end <= 0 {
+			continue
+		}
+
+		key := strings.TrimSpace(line[:end])
+		if strings.ContainsAny(key, " \t\"'") {
+			continue
+		}
+
+		lines[key] = i + 1
+	}
+
+	return lines
+}
+
+// apply combines the given variables, defined at the given locations, with
+// the environment using the given mode.
+func apply(values map[string]string, locations map[string]location, mode LoadMode, memory *memoryStore) error {
+	if mode == LoadModeInMemory {
+		memory.store(values, locations)
+
 		return nil
 	}
 
-	return godotenv.Overload(file)
+	set := make(map[string]string, len(values))
+
+	for k, v := range values {
+		if _, defined := os.LookupEnv(k); defined && mode == LoadModeEnvWins {
+			continue
+		}
+
+		if err := os.Setenv(k, v); err != nil {
+			return err
+		}
+
+		set[k] = v
+	}
+
+	memory.track(set, locations)
+
+	return nil
+}
+
+// store adds the given variables, defined at the given locations, to the
+// store, replacing existing ones.
+func (m *memoryStore) store(values map[string]string, locations map[string]location) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for k, v := range values {
+		m.values[k] = v
+		m.loaded[k] = loadedVar{value: v, loc: locations[k]}
+	}
 }
 
-func findDotEnv(dir string) string {
+// track records the given variables, defined at the given locations, as set
+// by Load in the process environment.
+func (m *memoryStore) track(values map[string]string, locations map[string]location) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for k, v := range values {
+		m.loaded[k] = loadedVar{value: v, loc: locations[k]}
+	}
+}
+
+// loadedFrom returns the location of the definition of the given variable, if
+// it was set by Load to the given value.
+func (m *memoryStore) loadedFrom(key, value string) (location, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	v, ok := m.loaded[key]
+	if !ok || v.value != value {
+		return location{}, false
+	}
+
+	return v.loc, true
+}
+
+// Lookup implements the Source interface.
+func (m *memoryStore) Lookup(key string) (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	v, ok := m.values[key]
+
+	return v, ok
+}
+
+// Keys implements the EnumerableSource interface.
+func (m *memoryStore) Keys() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]string, 0, len(m.values))
+
+	for k := range m.values {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// fileNames returns the dotenv file names to read, with the environment
+// placeholder resolved.
+func (o *options) fileNames() []string {
+	names := o.files
+	if names == nil {
+		names = defaultFiles
+	}
+
+	env := os.Getenv(environmentVar)
+	if o.environment != nil {
+		env = *o.environment
+	}
+
+	resolved := make([]string, 0, len(names))
+
+	for _, name := range names {
+		if strings.Contains(name, environmentPlaceholder) {
+			if env == "" {
+				continue
+			}
+
+			name = strings.ReplaceAll(name, environmentPlaceholder, env)
+		}
+
+		resolved = append(resolved, name)
+	}
+
+	return resolved
+}
+
+// findDotEnvFiles walks up from the given directory looking for the first one
+// containing any of the given file names, and returns the paths of those
+// found in it, preserving the given order. The search stops at the first
+// directory containing any of the given root markers.
+func findDotEnvFiles(dir string, names []string, markers []string) []string {
 	for {
-		file := filepath.Join(dir, ".env")
-		if _, err := os.Stat(file); !os.IsNotExist(err) {
-			return file
+		var files []string
+
+		for _, name := range names {
+			file := filepath.Join(dir, name)
+			if _, err := os.Stat(file); !os.IsNotExist(err) {
+				files = append(files, file)
+			}
+		}
+
+		if len(files) > 0 {
+			return files
+		}
+
+		for _, marker := range markers {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return nil
+			}
 		}
 
 		parent := "../"
 		next := filepath.Clean(filepath.Join(dir, parent))
 
 		if next == dir {
-			return ""
+			return nil
 		}
 
 		dir = next
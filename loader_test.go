@@ -0,0 +1,215 @@
+package dotenv_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tangelo-labs/go-dotenv"
+)
+
+func TestParse_DoesNotLoad(t *testing.T) {
+	t.Run("GIVEN a .env file in the working directory", func(t *testing.T) {
+		chdirTemp(t, map[string]string{
+			".env": "TEST_LOADER_IMPLICIT=file\n",
+		})
+
+		t.Run("WHEN parsing THEN the file is not loaded", func(t *testing.T) {
+			var env struct {
+				Implicit string `env:"TEST_LOADER_IMPLICIT" default:"default"`
+			}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, "default", env.Implicit)
+
+			_, defined := os.LookupEnv("TEST_LOADER_IMPLICIT")
+			require.False(t, defined)
+		})
+
+		t.Run("WHEN loading and parsing THEN the file is loaded", func(t *testing.T) {
+			var env struct {
+				Implicit string `env:"TEST_LOADER_IMPLICIT" default:"default"`
+			}
+
+			unsetAfter(t, "TEST_LOADER_IMPLICIT")
+
+			require.NoError(t, dotenv.LoadAndParse(&env))
+			require.Equal(t, "file", env.Implicit)
+		})
+	})
+}
+
+func TestLoadWithOptions(t *testing.T) {
+	t.Run("GIVEN a .env file in the working directory AND a variable already defined in the process environment", func(t *testing.T) {
+		chdirTemp(t, map[string]string{
+			".env": "TEST_LOADER_DEFINED=file\nTEST_LOADER_NEW=file\n",
+		})
+
+		t.Setenv("TEST_LOADER_DEFINED", "process")
+
+		t.Run("WHEN loading with default options THEN process variables are preserved", func(t *testing.T) {
+			unsetAfter(t, "TEST_LOADER_NEW")
+
+			require.NoError(t, dotenv.LoadWithOptions())
+			require.Equal(t, "process", os.Getenv("TEST_LOADER_DEFINED"))
+			require.Equal(t, "file", os.Getenv("TEST_LOADER_NEW"))
+		})
+
+		t.Run("WHEN loading in file-wins mode THEN process variables are overwritten", func(t *testing.T) {
+			unsetAfter(t, "TEST_LOADER_NEW")
+
+			require.NoError(t, dotenv.LoadWithOptions(dotenv.WithLoadMode(dotenv.LoadModeFileWins)))
+			require.Equal(t, "file", os.Getenv("TEST_LOADER_DEFINED"))
+			require.Equal(t, "file", os.Getenv("TEST_LOADER_NEW"))
+		})
+	})
+
+	t.Run("GIVEN a .env file in the working directory", func(t *testing.T) {
+		chdirTemp(t, map[string]string{
+			".env": "TEST_LOADER_MEMORY=memory\n",
+		})
+
+		t.Run("WHEN loading in in-memory mode THEN the process environment is untouched AND values are visible to Parse", func(t *testing.T) {
+			var env struct {
+				Memory string `env:"TEST_LOADER_MEMORY"`
+			}
+
+			require.NoError(t, dotenv.LoadWithOptions(dotenv.WithLoadMode(dotenv.LoadModeInMemory)))
+
+			_, defined := os.LookupEnv("TEST_LOADER_MEMORY")
+			require.False(t, defined)
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, "memory", env.Memory)
+
+			_, defined = os.LookupEnv("TEST_LOADER_MEMORY")
+			require.False(t, defined)
+		})
+	})
+}
+
+func TestLoadWithOptions_Cascade(t *testing.T) {
+	t.Run("GIVEN a directory with the conventional cascade of dotenv files", func(t *testing.T) {
+		dir := writeTemp(t, map[string]string{
+			".env":               "TEST_CASCADE_A=env\nTEST_CASCADE_B=env\nTEST_CASCADE_C=env\nTEST_CASCADE_D=env\n",
+			".env.local":         "TEST_CASCADE_B=local\nTEST_CASCADE_C=local\nTEST_CASCADE_D=local\n",
+			".env.staging":       "TEST_CASCADE_C=staging\nTEST_CASCADE_D=staging\n",
+			".env.staging.local": "TEST_CASCADE_D=staging.local\n",
+		})
+
+		t.Run("WHEN loading for the staging environment THEN more specific files take precedence", func(t *testing.T) {
+			unsetAfter(t, "TEST_CASCADE_A", "TEST_CASCADE_B", "TEST_CASCADE_C", "TEST_CASCADE_D")
+
+			require.NoError(t, dotenv.LoadWithOptions(
+				dotenv.WithSearchDir(dir),
+				dotenv.WithEnvironment("staging"),
+				dotenv.WithLoadMode(dotenv.LoadModeFileWins),
+			))
+
+			require.Equal(t, "env", os.Getenv("TEST_CASCADE_A"))
+			require.Equal(t, "local", os.Getenv("TEST_CASCADE_B"))
+			require.Equal(t, "staging", os.Getenv("TEST_CASCADE_C"))
+			require.Equal(t, "staging.local", os.Getenv("TEST_CASCADE_D"))
+		})
+
+		t.Run("WHEN loading with no environment THEN environment specific files are ignored", func(t *testing.T) {
+			unsetAfter(t, "TEST_CASCADE_A", "TEST_CASCADE_B", "TEST_CASCADE_C", "TEST_CASCADE_D")
+			t.Setenv("APP_ENV", "")
+
+			require.NoError(t, dotenv.LoadWithOptions(
+				dotenv.WithSearchDir(dir),
+				dotenv.WithLoadMode(dotenv.LoadModeFileWins),
+			))
+
+			require.Equal(t, "local", os.Getenv("TEST_CASCADE_D"))
+		})
+
+		t.Run("WHEN loading with custom file names THEN only such files are read", func(t *testing.T) {
+			unsetAfter(t, "TEST_CASCADE_A", "TEST_CASCADE_B", "TEST_CASCADE_C", "TEST_CASCADE_D")
+
+			require.NoError(t, dotenv.LoadWithOptions(
+				dotenv.WithSearchDir(dir),
+				dotenv.WithFiles(".env.{env}"),
+				dotenv.WithEnvironment("staging"),
+				dotenv.WithLoadMode(dotenv.LoadModeFileWins),
+			))
+
+			_, defined := os.LookupEnv("TEST_CASCADE_A")
+			require.False(t, defined)
+			require.Equal(t, "staging", os.Getenv("TEST_CASCADE_D"))
+		})
+	})
+
+	t.Run("GIVEN a .env file above a repository root marker", func(t *testing.T) {
+		dir := writeTemp(t, map[string]string{
+			".env":             "TEST_CASCADE_ROOT=outside\n",
+			"repo/go.mod":      "module example.com/repo\n",
+			"repo/sub/main.go": "package main\n",
+		})
+
+		t.Run("WHEN loading from within the repository with root markers THEN the search stops at the root", func(t *testing.T) {
+			unsetAfter(t, "TEST_CASCADE_ROOT")
+
+			require.NoError(t, dotenv.LoadWithOptions(
+				dotenv.WithSearchDir(filepath.Join(dir, "repo", "sub")),
+				dotenv.WithRootMarkers("go.mod", ".git"),
+			))
+
+			_, defined := os.LookupEnv("TEST_CASCADE_ROOT")
+			require.False(t, defined)
+		})
+
+		t.Run("WHEN loading from within the repository without root markers THEN the outer file is found", func(t *testing.T) {
+			unsetAfter(t, "TEST_CASCADE_ROOT")
+
+			require.NoError(t, dotenv.LoadWithOptions(dotenv.WithSearchDir(filepath.Join(dir, "repo", "sub"))))
+			require.Equal(t, "outside", os.Getenv("TEST_CASCADE_ROOT"))
+		})
+	})
+}
+
+// writeTemp creates a new temporary directory containing the given files.
+func writeTemp(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+// chdirTemp changes the working directory to a new temporary directory
+// containing the given files, restoring it once the test finishes.
+func chdirTemp(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := writeTemp(t, files)
+
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+
+	t.Cleanup(func() {
+		require.NoError(t, os.Chdir(cwd))
+	})
+
+	return dir
+}
+
+// unsetAfter unsets the given variables once the test finishes.
+func unsetAfter(t *testing.T, names ...string) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		for _, name := range names {
+			require.NoError(t, os.Unsetenv(name))
+		}
+	})
+}
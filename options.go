@@ -0,0 +1,173 @@
+package dotenv
+
+import "reflect"
+
+// Option configures the behavior of a Parser, or of a single call to Parse,
+// Load and their related functions.
+type Option func(*options)
+
+// options holds the configuration built from a list of Option.
+type options struct {
+	lenient  bool
+	expand   bool
+	parsers  map[reflect.Type]ParserFunc
+	sources  []Source
+	tagNames TagNames
+	hooks    []Hook
+	loadMode LoadMode
+
+	fileSuffix  string
+	maxFileSize int64
+
+	files       []string
+	environment *string
+	rootMarkers []string
+	searchDir   string
+
+	// ctxOverrides are the overrides held by the context given to
+	// ParseContext, if any.
+	ctxOverrides map[string]string
+
+	// secret reports whether the field being parsed holds a secret, whose
+	// raw value must be masked in errors.
+	secret bool
+
+	// defined holds the paths of the fields whose variable is defined,
+	// recorded while parsing so that their zero values are validated.
+	defined map[string]bool
+
+	// readOnly reports whether the struct being walked must be left
+	// untouched, nil pointers being described as zero values instead of
+	// being allocated.
+	readOnly bool
+
+	// provenance is the map given to WithProvenance, if any.
+	provenance map[string]Provenance
+
+	// defaults are the raw default values of the fields of the struct being
+	// parsed, indexed by variable name.
+	defaults map[string]string
+
+	// registry and memory are owned by the Parser these options belong to.
+	registry *parserRegistry
+	memory   *memoryStore
+}
+
+// TagNames holds the names of the struct tags read by a Parser. Empty names
+// are replaced by their default value.
+type TagNames struct {
+	// Env is the tag holding the variable name and its options, by default
+	// `env`.
+	Env string
+
+	// Default is the tag holding the default value, by default `default`.
+	Default string
+
+	// Prefix is the tag holding the prefix of nested structs, by default
+	// `envPrefix`.
+	Prefix string
+
+	// Delimiter is the tag holding the separator of slice and map entries, by
+	// default `delimiter`.
+	Delimiter string
+
+	// KVSeparator is the tag holding the separator of map keys and values, by
+	// default `kvSeparator`.
+	KVSeparator string
+
+	// TimeLayout is the tag holding the layout of time.Time values, by default
+	// `timeLayout`.
+	TimeLayout string
+}
+
+// defaultTagNames are the tag names used when none is given.
+var defaultTagNames = TagNames{
+	Env:         "env",
+	Default:     "default",
+	Prefix:      "envPrefix",
+	Delimiter:   "delimiter",
+	KVSeparator: "kvSeparator",
+	TimeLayout:  "timeLayout",
+}
+
+// Hook is a function invoked with the given struct once all of its fields
+// have been successfully injected.
+type Hook func(st interface{}) error
+
+// WithLenientConversion makes Parse write the zero value of a field when its
+// environment variable cannot be converted to the field's type, instead of
+// returning an ErrInvalidValue error.
+//
+// This mimics the behavior of previous versions of this package and should
+// only be used as a migration aid.
+func WithLenientConversion() Option {
+	return func(o *options) {
+		o.lenient = true
+	}
+}
+
+// WithTagNames sets the names of the struct tags to read. Empty names keep
+// their default value.
+func WithTagNames(names TagNames) Option {
+	return func(o *options) {
+		o.tagNames = mergeTagNames(o.tagNames, names)
+	}
+}
+
+// WithHook adds a function to be invoked once all the fields of a struct have
+// been successfully injected. Hooks are invoked in the order they were added,
+// and the first error returned by any of them is returned by Parse.
+func WithHook(h Hook) Option {
+	return func(o *options) {
+		hooks := make([]Hook, 0, len(o.hooks)+1)
+		o.hooks = append(append(hooks, o.hooks...), h)
+	}
+}
+
+// lookup resolves the given variable through the context overrides, if any,
+// and then through the configured chain of sources.
+func (o *options) lookup(key string) (string, bool) {
+	if v, ok := o.ctxOverrides[key]; ok {
+		if v == Unset {
+			return "", false
+		}
+
+		return v, true
+	}
+
+	if o.sources == nil {
+		return lookupSources(o.defaultSources(), key)
+	}
+
+	return lookupSources(o.sources, key)
+}
+
+// defaultSources returns the chain of sources used when none is given.
+func (o *options) defaultSources() []Source {
+	return []Source{
+		OverrideSource(),
+		EnvSource(),
+		o.memory,
+	}
+}
+
+// mergeTagNames returns the given base names with the non-empty names given
+// as overrides.
+func mergeTagNames(base, overrides TagNames) TagNames {
+	pick := func(b, o string) string {
+		if o != "" {
+			return o
+		}
+
+		return b
+	}
+
+	return TagNames{
+		Env:         pick(base.Env, overrides.Env),
+		Default:     pick(base.Default, overrides.Default),
+		Prefix:      pick(base.Prefix, overrides.Prefix),
+		Delimiter:   pick(base.Delimiter, overrides.Delimiter),
+		KVSeparator: pick(base.KVSeparator, overrides.KVSeparator),
+		TimeLayout:  pick(base.TimeLayout, overrides.TimeLayout),
+	}
+}
@@ -1,33 +1,46 @@
 package dotenv
 
 import (
+	"errors"
 	"fmt"
-	"reflect"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 )
 
-// maxStackLen is the maximum number of stack frames to inspect for the WithOverride call.
-const maxStackLen = 500
-
-// packageMarker is a marker type to get the package path of the WithOverride function.
-type packageMarker struct{}
+// Unset may be given as the value of an overridden variable to make it
+// undefined within the override, even if it is defined by the process
+// environment or any other source.
+//
+// Typical Usage Example:
+//
+//	dotenv.WithOverride(func() {
+//		err := dotenv.Parse(&cfg) // fails if FOO is a required variable
+//	}, "FOO", dotenv.Unset)
+//
+// It is honoured by every Source, so it may also be used with
+// ContextWithOverrides or MapSource.
+const Unset = "\x00dotenv:unset\x00"
 
-var (
-	// withOverridePackagePath is the package path of the WithOverride function.
-	withOverridePackagePath = reflect.TypeOf(packageMarker{}).PkgPath() + ".WithOverride"
+// ErrInvalidOverride is returned when overrides are given as an odd number of
+// arguments.
+var ErrInvalidOverride = errors.New("invalid override")
 
-	// overrideStack is a stack that holds the overridden environment variables indexed by goroutine ID.
-	overrideStack = sync.Map{}
-)
+// overrideStack holds the stack of variables overridden by the enclosing
+// WithOverride calls of each goroutine, indexed by goroutine ID.
+var overrideStack = sync.Map{}
 
 // WithOverride overrides the environment variables with the given ones
 // and restores them after the callback is executed.
 //
 // Any call to the Parse, LoadAndParse or similar within the callback will be
-// affected by the overridden values.
+// affected by the overridden values. Nested calls are merged, overrides of the
+// innermost call taking precedence, and variables can be made undefined using
+// the Unset value. Note that overrides are not seen by
+// goroutines spawned within the callback, see ContextWithOverrides for such
+// cases.
 //
 // This function will panic if the number of arguments is not even, or if there is
 // an error setting or unsetting the environment variables.
@@ -42,9 +55,6 @@ func WithOverride(callback func(), kv ...string) {
 		panic("dotenv.WithOverride requires an even number of arguments")
 	}
 
-	pc := make([]uintptr, 1)
-	runtime.Callers(2, pc)
-
 	tuples := make(map[string]string, len(kv)/2)
 
 	for i := 0; i < len(kv); i += 2 {
@@ -54,48 +64,129 @@ func WithOverride(callback func(), kv ...string) {
 		tuples[k] = v
 	}
 
-	key := fmt.Sprintf("%d:%d:%s", goid(), pc[0], runtime.FuncForPC(pc[0]).Name())
+	id := pushOverrides(tuples)
+	defer popOverrides(id)
 
-	overrideStack.Store(key, tuples)
 	callback()
-	overrideStack.Delete(key)
 }
 
-// isOverriddenCall checks if the current execution context is within a WithOverride call.
-func isOverriddenCall() (map[string]string, bool) {
-	var pc [maxStackLen]uintptr
+// WithOverrideE is like WithOverride, but the callback may return an error
+// which is returned by this function.
+//
+// Instead of panicking, an error matching ErrInvalidOverride is returned if
+// the number of arguments is not even. Overrides are restored even if the
+// callback panics.
+func WithOverrideE(callback func() error, kv ...string) error {
+	if len(kv)%2 != 0 {
+		return fmt.Errorf("%w: an even number of arguments is required, got %d", ErrInvalidOverride, len(kv))
+	}
 
-	n := runtime.Callers(2, pc[:])
-	override := false
-	tuples := make(map[string]string)
+	var err error
 
-	for i := 0; i < n; i++ {
-		f := runtime.FuncForPC(pc[i])
-		if f == nil {
-			continue
-		}
+	WithOverride(func() {
+		err = callback()
+	}, kv...)
+
+	return err
+}
+
+// WithOverrideValue is like WithOverride, but the overridden variables are
+// given as a map, and the value and error returned by the callback are
+// returned by this function.
+//
+// Overrides are restored even if the callback panics.
+//
+// Typical Usage Example:
+//
+//	cfg, err := dotenv.WithOverrideValue(func() (Config, error) {
+//		var cfg Config
+//
+//		return cfg, dotenv.Parse(&cfg)
+//	}, map[string]string{"FOO": "bar"})
+func WithOverrideValue[T any](callback func() (T, error), tuples map[string]string) (T, error) {
+	var (
+		out T
+		err error
+	)
+
+	WithOverride(func() {
+		out, err = callback()
+	}, mapToKV(tuples)...)
+
+	return out, err
+}
+
+// mapToKV flattens the given map into a list of key/value pairs sorted by key.
+func mapToKV(tuples map[string]string) []string {
+	keys := make([]string, 0, len(tuples))
 
-		if f.Name() == withOverridePackagePath {
-			override = true
-			overrider := runtime.FuncForPC(pc[i+1])
+	for k := range tuples {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	kv := make([]string, 0, len(tuples)*2)
+
+	for _, k := range keys {
+		kv = append(kv, k, tuples[k])
+	}
+
+	return kv
+}
+
+// pushOverrides pushes the given overridden variables onto the stack of the
+// current goroutine, whose ID is returned.
+func pushOverrides(tuples map[string]string) int {
+	id := goid()
+
+	var frames []map[string]string
+	if found, ok := overrideStack.Load(id); ok {
+		frames, _ = found.([]map[string]string)
+	}
+
+	overrideStack.Store(id, append(frames, tuples))
+
+	return id
+}
+
+// popOverrides pops the innermost overridden variables off the stack of the
+// given goroutine.
+func popOverrides(id int) {
+	found, ok := overrideStack.Load(id)
+	if !ok {
+		return
+	}
+
+	frames, _ := found.([]map[string]string)
+	if len(frames) <= 1 {
+		overrideStack.Delete(id)
+
+		return
+	}
+
+	overrideStack.Store(id, frames[:len(frames)-1])
+}
 
-			if overrider != nil {
-				key := fmt.Sprintf("%d:%d:%s", goid(), pc[i+1], overrider.Name())
-				found, ok := overrideStack.Load(key)
+// isOverriddenCall checks if the current execution context is within one or
+// more WithOverride calls, in which case the overridden variables of every
+// enclosing call are merged, the innermost call taking precedence.
+func isOverriddenCall() (map[string]string, bool) {
+	found, ok := overrideStack.Load(goid())
+	if !ok {
+		return nil, false
+	}
 
-				if ok {
-					pairs, validType := found.(map[string]string)
-					if validType {
-						tuples = pairs
-					}
-				}
-			}
+	frames, _ := found.([]map[string]string)
+	tuples := make(map[string]string)
 
-			break
+	for _, pairs := range frames {
+		for k, v := range pairs {
+			tuples[k] = v
 		}
 	}
 
-	return tuples, override
+	return tuples, len(frames) > 0
 }
 
 // goid returns the goroutine ID of the current goroutine.
@@ -1,10 +1,12 @@
 package dotenv_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/brianvoe/gofakeit/v6"
+	"github.com/stretchr/testify/require"
 	"github.com/tangelo-labs/go-dotenv"
 )
 
@@ -145,6 +147,154 @@ func testOverride(t *testing.T) {
 	}, "CONCURRENT_STRING", val)
 }
 
+func Test_OverrideT1(t *testing.T) {
+	t.Parallel()
+
+	testOverrideT(t)
+}
+
+func Test_OverrideT2(t *testing.T) {
+	t.Parallel()
+
+	testOverrideT(t)
+}
+
+func Test_OverrideT3(t *testing.T) {
+	t.Parallel()
+
+	testOverrideT(t)
+}
+
+func Test_OverrideT4(t *testing.T) {
+	t.Parallel()
+
+	testOverrideT(t)
+}
+
+func Test_OverrideT5(t *testing.T) {
+	t.Parallel()
+
+	testOverrideT(t)
+}
+
+func Test_OverrideTOddArguments(t *testing.T) {
+	t.Parallel()
+
+	ft := &fakeTB{TB: t}
+
+	dotenv.OverrideT(ft, "CONCURRENT_STRING")
+
+	require.True(t, ft.failed)
+	require.Contains(t, ft.message, "even number of arguments")
+}
+
+func Test_OverrideMapT(t *testing.T) {
+	t.Parallel()
+
+	dotenv.OverrideMapT(t, map[string]string{"CONCURRENT_STRING": "from-map"})
+
+	var env tEnv
+
+	require.NoError(t, dotenv.Parse(&env))
+	require.Equal(t, "from-map", env.ConcurrentValue)
+}
+
+func Test_OverrideTSubtests(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GIVEN a variable overridden by a test", func(t *testing.T) {
+		dotenv.OverrideT(t, "CONCURRENT_STRING", "parent")
+
+		t.Run("WHEN parsing within a nested subtest THEN the override is seen", func(t *testing.T) {
+			t.Run("AND deeper", func(t *testing.T) {
+				var env tEnv
+
+				require.NoError(t, dotenv.Parse(&env))
+				require.Equal(t, "parent", env.ConcurrentValue)
+			})
+		})
+
+		t.Run("WHEN parsing within a parallel subtest THEN the override is seen", func(t *testing.T) {
+			t.Parallel()
+
+			var env tEnv
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, "parent", env.ConcurrentValue)
+		})
+
+		t.Run("WHEN a subtest overrides it again THEN the innermost override wins", func(t *testing.T) {
+			dotenv.OverrideT(t, "CONCURRENT_STRING", "child")
+
+			t.Run("AND parsing within a nested subtest", func(t *testing.T) {
+				var env tEnv
+
+				require.NoError(t, dotenv.Parse(&env))
+				require.Equal(t, "child", env.ConcurrentValue)
+			})
+		})
+
+		t.Run("WHEN parsing within a sibling subtest THEN the parent override is seen", func(t *testing.T) {
+			var env tEnv
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, "parent", env.ConcurrentValue)
+		})
+	})
+}
+
+func testOverrideT(t *testing.T) {
+	gofakeit := gofakeit.New(time.Now().UnixNano())
+	val := gofakeit.Sentence(10)
+
+	dotenv.OverrideT(t, "CONCURRENT_STRING", val)
+
+	var env tEnv
+
+	if err := dotenv.LoadAndParse(&env); err != nil {
+		t.Errorf("failed to load env: %s", err)
+	}
+
+	if env.ConcurrentValue != val {
+		t.Errorf("expected %s, got %s", val, env.ConcurrentValue)
+	}
+
+	dotenv.WithOverride(func() {
+		if err := dotenv.Parse(&env); err != nil {
+			t.Errorf("failed to parse env: %s", err)
+		}
+
+		if env.ConcurrentValue != "inner" {
+			t.Errorf("expected inner, got %s", env.ConcurrentValue)
+		}
+	}, "CONCURRENT_STRING", "inner")
+
+	dotenv.OverrideT(t, "CONCURRENT_STRING", dotenv.Unset)
+
+	if err := dotenv.Parse(&env); err != nil {
+		t.Errorf("failed to parse env: %s", err)
+	}
+
+	if env.ConcurrentValue != "bar" {
+		t.Errorf("expected bar, got %s", env.ConcurrentValue)
+	}
+}
+
+// fakeTB records test failures instead of stopping the test.
+type fakeTB struct {
+	testing.TB
+
+	failed  bool
+	message string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Fatalf(format string, args ...interface{}) {
+	f.failed = true
+	f.message = fmt.Sprintf(format, args...)
+}
+
 type tEnv struct {
 	ConcurrentValue string `env:"CONCURRENT_STRING" default:"bar"`
 }
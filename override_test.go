@@ -1,6 +1,7 @@
 package dotenv_test
 
 import (
+	"errors"
 	"os"
 	"sync"
 	"testing"
@@ -92,6 +93,44 @@ func TestWithOverride(t *testing.T) {
 		})
 	})
 
+	t.Run("GIVEN an environment struct with default values", func(t *testing.T) {
+		var vars testEnv
+
+		t.Run("WHEN nesting overrides of different variables THEN inner callbacks see the merged overrides AND the innermost wins", func(t *testing.T) {
+			dotenv.WithOverride(func() {
+				dotenv.WithOverride(func() {
+					require.NoError(t, dotenv.Parse(&vars))
+					require.EqualValues(t, "inner", vars.Foo)
+					require.EqualValues(t, 24, vars.TheMeaningOfLifeTheUniverseAndEverything)
+					require.EqualValues(t, []string{"a", "b"}, vars.FakeList)
+				},
+					"FOO", "inner",
+					"FAKE_LIST", "a,b",
+				)
+			},
+				"FOO", "outer",
+				"DUMMY", "24",
+			)
+		})
+	})
+
+	t.Run("GIVEN a required variable defined in the process environment", func(t *testing.T) {
+		t.Setenv("TEST_STRING_REQUIRED", "defined")
+
+		t.Run("WHEN overriding it as unset THEN it is seen as undefined", func(t *testing.T) {
+			dotenv.WithOverride(func() {
+				var env dummyStructWithRequire
+
+				require.ErrorIs(t, dotenv.Parse(&env), dotenv.ErrRequiredField)
+
+				dotenv.WithOverride(func() {
+					require.NoError(t, dotenv.Parse(&env))
+					require.Equal(t, "again", env.String)
+				}, "TEST_STRING_REQUIRED", "again")
+			}, "TEST_STRING_REQUIRED", dotenv.Unset)
+		})
+	})
+
 	t.Run("GIVEN an environment variable", func(t *testing.T) {
 		require.NoError(t, os.Setenv("GIT_GUT", "lol"))
 
@@ -138,3 +177,108 @@ type testEnv struct {
 	FakeList                                 []string `env:"FAKE_LIST" default:"foo,bar" delimiter:","`
 	ConcurrentString                         string   `env:"CONCURRENT_STRING" default:"foo"`
 }
+
+func TestWithOverrideE(t *testing.T) {
+	t.Run("GIVEN a callback returning an error", func(t *testing.T) {
+		errCallback := errors.New("callback failed")
+
+		t.Run("WHEN overriding THEN the callback sees the overrides AND its error is returned", func(t *testing.T) {
+			err := dotenv.WithOverrideE(func() error {
+				var env testEnv
+
+				require.NoError(t, dotenv.Parse(&env))
+				require.Equal(t, "overridden", env.Foo)
+
+				return errCallback
+			}, "FOO", "overridden")
+
+			require.ErrorIs(t, err, errCallback)
+		})
+
+		t.Run("WHEN overriding with an odd number of arguments THEN an error is returned", func(t *testing.T) {
+			err := dotenv.WithOverrideE(func() error {
+				return nil
+			}, "FOO")
+
+			require.ErrorIs(t, err, dotenv.ErrInvalidOverride)
+		})
+
+		t.Run("WHEN nesting overrides THEN inner callbacks see the merged overrides AND outer ones are kept afterwards", func(t *testing.T) {
+			err := dotenv.WithOverrideE(func() error {
+				var env testEnv
+
+				innerErr := dotenv.WithOverrideE(func() error {
+					require.NoError(t, dotenv.Parse(&env))
+					require.Equal(t, "inner", env.Foo)
+					require.Equal(t, 24, env.TheMeaningOfLifeTheUniverseAndEverything)
+
+					return nil
+				}, "FOO", "inner")
+
+				require.NoError(t, innerErr)
+				require.NoError(t, dotenv.Parse(&env))
+				require.Equal(t, "outer", env.Foo)
+				require.Equal(t, 24, env.TheMeaningOfLifeTheUniverseAndEverything)
+
+				return errCallback
+			}, "FOO", "outer", "DUMMY", "24")
+
+			require.ErrorIs(t, err, errCallback)
+		})
+	})
+
+	t.Run("GIVEN a callback that panics", func(t *testing.T) {
+		t.Run("WHEN overriding THEN overrides are restored", func(t *testing.T) {
+			require.Panics(t, func() {
+				_ = dotenv.WithOverrideE(func() error {
+					panic("boom")
+				}, "FOO", "overridden")
+			})
+
+			var env testEnv
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, "bar", env.Foo)
+		})
+	})
+}
+
+func TestWithOverrideValue(t *testing.T) {
+	t.Run("GIVEN a callback returning a parsed struct", func(t *testing.T) {
+		t.Run("WHEN overriding with a map THEN the parsed value is returned", func(t *testing.T) {
+			env, err := dotenv.WithOverrideValue(func() (testEnv, error) {
+				var env testEnv
+
+				return env, dotenv.Parse(&env)
+			}, map[string]string{"FOO": "from-map", "DUMMY": "7"})
+
+			require.NoError(t, err)
+			require.Equal(t, "from-map", env.Foo)
+			require.Equal(t, 7, env.TheMeaningOfLifeTheUniverseAndEverything)
+		})
+
+		t.Run("WHEN the callback fails THEN its error is returned", func(t *testing.T) {
+			_, err := dotenv.WithOverrideValue(func() (testEnv, error) {
+				var env testEnv
+
+				return env, dotenv.Parse(&env)
+			}, map[string]string{"DUMMY": "seven"})
+
+			require.ErrorIs(t, err, dotenv.ErrInvalidValue)
+		})
+
+		t.Run("WHEN nesting overrides THEN inner callbacks see the merged overrides", func(t *testing.T) {
+			env, err := dotenv.WithOverrideValue(func() (testEnv, error) {
+				return dotenv.WithOverrideValue(func() (testEnv, error) {
+					var env testEnv
+
+					return env, dotenv.Parse(&env)
+				}, map[string]string{"FOO": "inner"})
+			}, map[string]string{"FOO": "outer", "DUMMY": "7"})
+
+			require.NoError(t, err)
+			require.Equal(t, "inner", env.Foo)
+			require.Equal(t, 7, env.TheMeaningOfLifeTheUniverseAndEverything)
+		})
+	})
+}
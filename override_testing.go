@@ -0,0 +1,207 @@
+package dotenv
+
+import (
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+var (
+	// goroutineOverrides holds the variables overridden by OverrideT indexed
+	// by goroutine ID.
+	goroutineOverrides = sync.Map{}
+
+	// goroutineOverridesCount is the number of goroutines with overrides
+	// installed by OverrideT, used to skip lookups when there are none.
+	goroutineOverridesCount int32
+
+	// goroutineParents caches the ID of the goroutine which created each
+	// goroutine, or zero when unknown. Goroutine IDs are never reused.
+	goroutineParents = sync.Map{}
+
+	goroutineHeader  = regexp.MustCompile(`^goroutine (\d+) `)
+	goroutineCreator = regexp.MustCompile(`created by .* in goroutine (\d+)`)
+)
+
+// OverrideT overrides the given environment variables for the rest of the
+// given test, restoring them once the test and all its subtests complete.
+//
+// Unlike t.Setenv, overrides never touch the process environment, so this
+// function is safe to use in tests calling t.Parallel. Overrides are seen by
+// any call to Parse, LoadAndParse or similar performed by the goroutine
+// running the test, by its subtests started using t.Run, and by any goroutine
+// started from them, including the Unset value. Overrides set by a subtest
+// take precedence over the ones set by its parents.
+//
+// Overrides set by enclosing WithOverride calls take precedence over the ones
+// set by this function. Calling this function several times within the same
+// test merges the given overrides, the latest call taking precedence.
+//
+// The test fails if the number of arguments is not even.
+//
+// Typical Usage Example:
+//
+//	func TestSomething(t *testing.T) {
+//		t.Parallel()
+//
+//		dotenv.OverrideT(t, "FOO", "bar")
+//
+//		functionThatCallsLoadAndParse()
+//	}
+func OverrideT(t testing.TB, kv ...string) {
+	t.Helper()
+
+	if len(kv)%2 != 0 {
+		t.Fatalf("dotenv.OverrideT requires an even number of arguments, got %d: %q", len(kv), kv)
+
+		return
+	}
+
+	tuples := make(map[string]string, len(kv)/2)
+
+	for i := 0; i < len(kv); i += 2 {
+		tuples[kv[i]] = kv[i+1]
+	}
+
+	overrideGoroutine(t, tuples)
+}
+
+// OverrideMapT is like OverrideT, but the overridden variables are given as
+// a map.
+func OverrideMapT(t testing.TB, tuples map[string]string) {
+	t.Helper()
+
+	OverrideT(t, mapToKV(tuples)...)
+}
+
+// overrideGoroutine installs the given overrides for the current goroutine,
+// merged with any previous ones, and restores the previous ones once the
+// given test completes.
+func overrideGoroutine(t testing.TB, tuples map[string]string) {
+	id := goid()
+	previous, hadPrevious := goroutineOverrides.Load(id)
+	merged := make(map[string]string, len(tuples))
+
+	if hadPrevious {
+		if pairs, ok := previous.(map[string]string); ok {
+			for k, v := range pairs {
+				merged[k] = v
+			}
+		}
+	} else {
+		atomic.AddInt32(&goroutineOverridesCount, 1)
+	}
+
+	for k, v := range tuples {
+		merged[k] = v
+	}
+
+	goroutineOverrides.Store(id, merged)
+
+	t.Cleanup(func() {
+		if hadPrevious {
+			goroutineOverrides.Store(id, previous)
+
+			return
+		}
+
+		goroutineOverrides.Delete(id)
+		atomic.AddInt32(&goroutineOverridesCount, -1)
+	})
+}
+
+// lookupGoroutineOverride returns the value of the given variable if it was
+// overridden by OverrideT for the current goroutine, or for any goroutine
+// which created it, the innermost one taking precedence.
+func lookupGoroutineOverride(key string) (string, bool) {
+	if atomic.LoadInt32(&goroutineOverridesCount) == 0 {
+		return "", false
+	}
+
+	for _, id := range goroutineAncestry() {
+		found, ok := goroutineOverrides.Load(id)
+		if !ok {
+			continue
+		}
+
+		pairs, validType := found.(map[string]string)
+		if !validType {
+			continue
+		}
+
+		if v, ok := pairs[key]; ok {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// goroutineAncestry returns the ID of the current goroutine followed by the
+// IDs of the goroutines which created it, such as the goroutines running the
+// parents of the current test.
+func goroutineAncestry() []int {
+	ids := []int{goid()}
+
+	for {
+		parent := goroutineParent(ids[len(ids)-1])
+		if parent == 0 {
+			return ids
+		}
+
+		ids = append(ids, parent)
+	}
+}
+
+// goroutineParent returns the ID of the goroutine which created the given
+// one, or zero when unknown.
+func goroutineParent(id int) int {
+	if parent, ok := goroutineParents.Load(id); ok {
+		p, _ := parent.(int)
+
+		return p
+	}
+
+	loadGoroutineParents()
+
+	parent, _ := goroutineParents.LoadOrStore(id, 0)
+	p, _ := parent.(int)
+
+	return p
+}
+
+// loadGoroutineParents records the parent of every running goroutine, as
+// reported by the `created by ... in goroutine N` lines of their traces.
+func loadGoroutineParents() {
+	buf := make([]byte, 64<<10)
+
+	n := runtime.Stack(buf, true)
+	for n == len(buf) {
+		buf = make([]byte, 2*len(buf))
+		n = runtime.Stack(buf, true)
+	}
+
+	for _, trace := range strings.Split(string(buf[:n]), "\n\n") {
+		header := goroutineHeader.FindStringSubmatch(trace)
+		if header == nil {
+			continue
+		}
+
+		id, err := strconv.Atoi(header[1])
+		if err != nil {
+			continue
+		}
+
+		parent := 0
+
+		if creator := goroutineCreator.FindStringSubmatch(trace); creator != nil {
+			parent, _ = strconv.Atoi(creator[1])
+		}
+
+		goroutineParents.Store(id, parent)
+	}
+}
@@ -3,18 +3,16 @@ package dotenv
 import (
 	"errors"
 	"fmt"
-	"os"
 	"reflect"
-	"strings"
 	"time"
 
 	"github.com/fatih/structtag"
 )
 
 var (
-	timeType        = reflect.TypeOf(time.Time{})
-	durationType    = reflect.TypeOf(time.Duration(0))
-	stringSliceType = reflect.TypeOf([]string{})
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	bytesType    = reflect.TypeOf([]byte(nil))
 )
 
 // List of injection errors.
@@ -23,49 +21,53 @@ var (
 	ErrTimeLayoutRequired = errors.New("missing timeLayout tag")
 	ErrRequiredField      = errors.New("required field")
 	ErrEmptyField         = errors.New("empty field")
+	ErrInvalidValue       = errors.New("invalid value")
+	ErrExpansion          = errors.New("expansion failed")
+	ErrValidation         = errors.New("validation failed")
+	ErrFileValue          = errors.New("cannot read value from file")
 )
 
-var valueMapper = map[reflect.Kind]func(v value) interface{}{
-	reflect.Int: func(v value) interface{} {
+var valueMapper = map[reflect.Kind]func(v value) (interface{}, error){
+	reflect.Int: func(v value) (interface{}, error) {
 		return v.AsInt()
 	},
-	reflect.Int8: func(v value) interface{} {
+	reflect.Int8: func(v value) (interface{}, error) {
 		return v.AsInt8()
 	},
-	reflect.Int16: func(v value) interface{} {
+	reflect.Int16: func(v value) (interface{}, error) {
 		return v.AsInt16()
 	},
-	reflect.Int32: func(v value) interface{} {
+	reflect.Int32: func(v value) (interface{}, error) {
 		return v.AsInt32()
 	},
-	reflect.Int64: func(v value) interface{} {
+	reflect.Int64: func(v value) (interface{}, error) {
 		return v.AsInt64()
 	},
-	reflect.Uint: func(v value) interface{} {
+	reflect.Uint: func(v value) (interface{}, error) {
 		return v.AsUint()
 	},
-	reflect.Uint8: func(v value) interface{} {
+	reflect.Uint8: func(v value) (interface{}, error) {
 		return v.AsUint8()
 	},
-	reflect.Uint16: func(v value) interface{} {
+	reflect.Uint16: func(v value) (interface{}, error) {
 		return v.AsUint16()
 	},
-	reflect.Uint32: func(v value) interface{} {
+	reflect.Uint32: func(v value) (interface{}, error) {
 		return v.AsUint32()
 	},
-	reflect.Uint64: func(v value) interface{} {
+	reflect.Uint64: func(v value) (interface{}, error) {
 		return v.AsUint64()
 	},
-	reflect.Float32: func(v value) interface{} {
+	reflect.Float32: func(v value) (interface{}, error) {
 		return v.AsFloat32()
 	},
-	reflect.Float64: func(v value) interface{} {
+	reflect.Float64: func(v value) (interface{}, error) {
 		return v.AsFloat64()
 	},
-	reflect.String: func(v value) interface{} {
-		return v.AsString()
+	reflect.String: func(v value) (interface{}, error) {
+		return v.AsString(), nil
 	},
-	reflect.Bool: func(v value) interface{} {
+	reflect.Bool: func(v value) (interface{}, error) {
 		return v.AsBool()
 	},
 }
@@ -73,6 +75,16 @@ var valueMapper = map[reflect.Kind]func(v value) interface{}{
 // Parse injects environment variables into the given struct using tag
 // annotations.
 //
+// Parse resolves variables through the chain of sources given by the
+// WithSources option, by default overrides set by WithOverride, the process
+// environment and variables loaded in memory using the LoadModeInMemory mode
+// (see DefaultSources). It never loads dotenv files by itself. Previous
+// versions of this package implicitly called Load before parsing, such
+// behavior is still available through LoadAndParse.
+//
+// Parse uses the default Parser, with the given options applied on top of it.
+// Use New to build a Parser configured independently from the default one.
+//
 // The given argument must be a pointer to a struct where values will be
 // injected.
 //
@@ -92,10 +104,26 @@ var valueMapper = map[reflect.Kind]func(v value) interface{}{
 // field. In the case of `time.Time` fields, the `timeLayout` tag must be used
 // to specify the format of the time string.
 //
-// String slices:
+// Slices and arrays:
+//
+// Slices and arrays of any supported type are populated by splitting the value
+// using the separator given by the `delimiter` tag, by default `,` will be
+// used. Each element is converted using the same rules as plain fields, and
+// conversion errors report the index of the failing element. Byte slices are
+// populated with the raw value instead.
 //
-// Optionally, the tag `delimiter` may be used to specify a separator for string
-// slices, by default `,` will be used.
+// Maps:
+//
+// Maps of any supported key and value types are populated by splitting the
+// value into entries using the `delimiter` tag, and then each entry into a key
+// and a value using the separator given by the `kvSeparator` tag, by default
+// `:` will be used. Malformed entries and duplicated keys are reported as
+// errors:
+//
+//	type Config struct {
+//		Limits map[string]int	`env:"LIMITS"`	// LIMITS=tenantA:10,tenantB:20
+//		Flags  map[string]bool	`env:"FLAGS" delimiter:";" kvSeparator:"="`	// FLAGS=beta=true;dark=false
+//	}
 //
 // For example:
 //
@@ -107,92 +135,485 @@ var valueMapper = map[reflect.Kind]func(v value) interface{}{
 //	}
 //
 // Fields without an `env` tag will not be injected.
-func Parse(st interface{}) error {
-	if err := Load(); err != nil {
-		return err
-	}
+//
+// Defaults:
+//
+// Default values are expanded as described below when expansion is enabled
+// for the field, so they may refer to other variables, or to the default value
+// of other fields, such as `env:"ADDR,expand" default:"localhost:${HTTP_PORT}"`.
+// Otherwise they are used as is. Defaults which cannot be expressed using
+// tags, such as values derived from other fields, may be computed by
+// implementing the Defaulter interface.
+//
+// Expansion:
+//
+// References to other variables within values and defaults can be expanded
+// using the `expand` env option, or the WithExpansion option for every field.
+// References are resolved through the same sources as any other variable,
+// including overrides, and the following forms are supported:
+//
+//	${VAR}			the value of VAR, or empty if undefined
+//	${VAR:-fallback}	the value of VAR, or fallback if undefined or empty
+//	${VAR:?message}		the value of VAR, or an error with the given message if undefined or empty
+//	$$			a literal `$`
+//
+// Referenced values are expanded as well, cyclic references are reported as
+// errors matching ErrExpansion. For example:
+//
+//	type Config struct {
+//		DatabaseURL string	`env:"DATABASE_URL,expand" default:"postgres://${DB_USER}:${DB_PASS}@${DB_HOST:-localhost}/app"`
+//	}
+//
+// Nested structs:
+//
+// Untagged fields holding a struct, a pointer to a struct or an embedded
+// struct are populated recursively. Nil pointers are only allocated when the
+// struct they point to binds any variable, so pointers such as `*http.Client`
+// are left untouched, and self-referential types are only descended into
+// once. The `envPrefix` tag may be used to prefix the names of every variable
+// within the nested struct:
+//
+//	type Config struct {
+//		DB struct {
+//			Host string	`env:"HOST"`
+//		} `envPrefix:"DB_"`
+//	}
+//
+// In the example above, the `DB_HOST` variable will be injected into the
+// `Config.DB.Host` field. Prefixes of deeper nested structs are concatenated.
+//
+// Custom types:
+//
+// Fields whose type implements Decoder, encoding.TextUnmarshaler,
+// encoding.BinaryUnmarshaler or a flag.Value-style `Set(string) error` method,
+// either on value or pointer receivers, are decoded using such interfaces.
+// This allows types such as `net.IP`, `url.URL` or `*regexp.Regexp` to be
+// injected.
+//
+// Conversion functions for types that cannot implement such interfaces may be
+// registered using RegisterParser, or the WithParser option. Registered
+// parsers take precedence over any built-in conversion.
+//
+// Files:
+//
+// When a variable is not defined, but the same variable suffixed with `_FILE`
+// is, such as `DB_PASSWORD_FILE`, the value is read from the file it points
+// to. This allows secrets mounted as files by Docker or Kubernetes to be
+// injected. The suffix can be changed using WithFileSuffix.
+//
+// The `file` env option states that the variable itself holds the path of a
+// file whose contents must be injected:
+//
+//	type Config struct {
+//		TLSKey string	`env:"TLS_KEY,file"`	// TLS_KEY=/run/secrets/tls.key
+//	}
+//
+// In both cases, trailing line breaks are removed from the contents unless
+// the `noTrim` env option is given, and files larger than 1 MiB are rejected,
+// see WithMaxFileSize. Failures are reported as errors matching ErrFileValue
+// which name both the variable and the path of the file.
+//
+// Secrets:
+//
+// Fields of type Secret are never printed by the fmt, encoding/json and
+// log/slog packages, and their raw values are masked in the errors returned
+// by Parse. The `secret` env option masks the values of fields of any other
+// type in errors:
+//
+//	type Config struct {
+//		Password dotenv.Secret	`env:"DB_PASSWORD"`
+//		Token    string		`env:"TOKEN,secret"`
+//	}
+//
+// Validation:
+//
+// Once injected, and after calling any Defaulter, field values are checked
+// against the following tags:
+//
+//	min:"1"			numbers and durations must be greater than or equal to, strings, slices and maps must have at least as many elements
+//	max:"10"		numbers and durations must be lower than or equal to, strings, slices and maps must have at most as many elements
+//	len:"3"			strings, slices and maps must have exactly as many elements
+//	oneof:"debug info"	values must be any of the space separated values
+//	regex:"^[a-z]+$"	values must match the regular expression
+//	validate:"url"		values must be absolute URLs
+//	validate:"hostport"	values must be `host:port` addresses
+//	validate:"file"		values must be paths of existing files
+//	validate:"dir"		values must be paths of existing directories
+//
+// Several checks may be given to the `validate` tag separated by commas. The
+// `oneof`, `regex` and `validate` tags are applied to each element of slices
+// and arrays. Zero values of undefined variables are never validated, use the
+// `required` or `notEmpty` options to enforce their presence. Zero values of
+// defined variables, such as `WORKERS=0`, are only checked against the `min`,
+// `max` and `oneof` tags. Failures are reported as errors matching
+// ErrValidation.
+//
+// Finally, Validate is called on the given struct and on every nested struct
+// implementing the Validator interface, so that rules involving several fields
+// live next to the type.
+//
+// Errors:
+//
+// Every tagged field is processed even if some of them fail, in which case a
+// ValidationErrors value listing each failing field is returned. It can be
+// matched against ErrRequiredField, ErrEmptyField, ErrInvalidValue, etc. using
+// errors.Is.
+//
+// Each FieldError holds the Provenance of the value of its field, and values
+// read from files are reported along with their position, such as:
+//
+//	field `Port` at /srv/app/.env:12: invalid value: environment variable `PORT` with value `abc` cannot be converted to `int`: ...
+//
+// The provenance of every field can be recorded using WithProvenance.
+//
+// Conversion errors:
+//
+// If the value of a variable cannot be converted to the type of its field, an
+// error matching ErrInvalidValue will be returned. Use WithLenientConversion
+// to write the zero value of the field instead. Empty values are always
+// injected as the zero value of the field.
+func Parse(st interface{}, opts ...Option) error {
+	return defaultParser.With(opts...).Parse(st)
+}
 
-	val := reflect.ValueOf(st)
-	if val.Kind() != reflect.Ptr {
-		return fmt.Errorf("%w: given `%s` is not a pointer", ErrNotAPointer, val.Kind())
-	}
+// fieldVisitor is invoked by walkStruct for every field bound to an
+// environment variable.
+type fieldVisitor func(field reflect.Value, path, varName string, envOptions []string, tags *structtag.Tags) *FieldError
+
+// structVisitor is invoked by walkStruct for every visited struct, once all
+// of its fields have been visited. The given parent is the type of the struct
+// embedding the visited one, if any, so that methods promoted to the parent
+// are not invoked twice.
+type structVisitor func(val reflect.Value, path string, parent reflect.Type) *FieldError
+
+// parseStruct injects environment variables into every tagged field of the
+// given struct value, descending into nested structs, and then calls
+// SetDefaults on each struct implementing the Defaulter interface.
+func parseStruct(val reflect.Value, o *options) ValidationErrors {
+	return walkStruct(val, "", "", o,
+		func(field reflect.Value, path, varName string, envOptions []string, tags *structtag.Tags) *FieldError {
+			err := parseField(field, path, varName, envOptions, tags, o)
+			if err != nil {
+				p := provenanceOf(field, varName, tags, o)
+				err.Provenance = &p
+			}
+
+			return err
+		},
+		func(val reflect.Value, _ string, parent reflect.Type) *FieldError {
+			callDefaulter(val, parent)
+
+			return nil
+		},
+	)
+}
+
+// walkStruct visits every tagged field of the given struct value, descending
+// into nested structs. Variable names are prefixed with the given prefix, and
+// field paths with the given path.
+func walkStruct(val reflect.Value, prefix, path string, o *options, visitField fieldVisitor, visitStruct structVisitor) ValidationErrors {
+	return walkNested(val, prefix, path, nil, o, visitField, visitStruct, make(map[reflect.Type]bool))
+}
+
+// walkNested implements walkStruct. The given parent is the type of the
+// struct embedding the given one, if any. Struct types already being visited
+// are skipped, so self-referential types do not recurse forever.
+func walkNested(val reflect.Value, prefix, path string, parent reflect.Type, o *options, visitField fieldVisitor, visitStruct structVisitor, visiting map[reflect.Type]bool) ValidationErrors {
+	var errs ValidationErrors
 
-	val = val.Elem()
 	typ := val.Type()
 
-	if val.Kind() != reflect.Struct {
-		return fmt.Errorf("%w: given `%s` is not a pointer", ErrNotAPointer, val.Kind())
-	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
 
 	for idx := 0; idx < val.NumField(); idx++ {
 		field := val.Field(idx)
-		tag := typ.Field(idx).Tag
+		sf := typ.Field(idx)
+		fieldPath := path + sf.Name
 
-		tags, err := structtag.Parse(string(tag))
+		tags, err := structtag.Parse(string(sf.Tag))
 		if err != nil {
-			return err
+			errs = append(errs, &FieldError{Field: fieldPath, Err: err})
+
+			continue
 		}
 
-		envTag, err := tags.Get("env")
-		if err != nil {
+		envTag, err := tags.Get(o.tagNames.Env)
+		if err == nil {
+			if fErr := visitField(field, fieldPath, prefix+envTag.Name, envTag.Options, tags); fErr != nil {
+				errs = append(errs, fErr)
+			}
+
+			continue
+		}
+
+		nested, ok := nestedStruct(field, sf, o, visiting)
+		if !ok {
 			// skip not tagged fields
 			continue
 		}
 
-		defaultValue := ""
-		defaultTag, err := tags.Get("default")
+		childPrefix := prefix
+		if prefixTag, pErr := tags.Get(o.tagNames.Prefix); pErr == nil {
+			childPrefix += prefixTag.Name
+		}
 
-		if err == nil {
-			defaultValue = defaultTag.Name
+		var embeddedIn reflect.Type
+		if sf.Anonymous {
+			embeddedIn = typ
+		}
 
-			if len(defaultTag.Options) > 0 {
-				defaultValue = fmt.Sprintf("%s,%s", defaultValue, strings.Join(defaultTag.Options, ","))
-			}
+		errs = append(errs, walkNested(nested, childPrefix, fieldPath+".", embeddedIn, o, visitField, visitStruct, visiting)...)
+	}
+
+	if sErr := visitStruct(val, path, parent); sErr != nil {
+		errs = append(errs, sErr)
+	}
+
+	return errs
+}
+
+// nestedStruct returns the struct value to descend into for the given field.
+// Nil pointers are only allocated when the struct they point to binds any
+// environment variable, or when embedded and implementing Defaulter or
+// Validator, as their methods are promoted to the embedding struct. On
+// read-only walks, an unaddressable zero value is returned instead. It
+// returns false if the field is not a struct, or a pointer to a struct, that
+// can be populated, or if its type is already being visited.
+func nestedStruct(field reflect.Value, sf reflect.StructField, o *options, visiting map[reflect.Type]bool) (reflect.Value, bool) {
+	typ, ok := nestedStructType(sf)
+	if !ok || visiting[typ] {
+		return reflect.Value{}, false
+	}
+
+	if field.Kind() != reflect.Ptr {
+		return field, true
+	}
+
+	if field.IsNil() {
+		ptr := reflect.PointerTo(typ)
+		hooked := sf.Anonymous && (ptr.Implements(defaulterType) || ptr.Implements(validatorType))
+
+		if !hooked && !bindsVariables(typ, o, visiting) {
+			return reflect.Value{}, false
 		}
 
-		isRequired := false
-		notEmpty := false
+		if o.readOnly {
+			return reflect.Zero(typ), true
+		}
 
-		for i := range envTag.Options {
-			if envTag.Options[i] == "required" {
-				isRequired = true
-			}
+		if !field.CanSet() {
+			return reflect.Value{}, false
+		}
 
-			if envTag.Options[i] == "notEmpty" {
-				notEmpty = true
-			}
+		field.Set(reflect.New(typ))
+	}
+
+	return field.Elem(), true
+}
+
+// nestedStructType returns the struct type to descend into for the given
+// untagged field. It returns false if the field is not an exported or
+// embedded struct, or pointer to a struct.
+func nestedStructType(sf reflect.StructField) (reflect.Type, bool) {
+	if sf.PkgPath != "" && !sf.Anonymous {
+		return nil, false
+	}
+
+	typ := sf.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct || typ == timeType {
+		return nil, false
+	}
+
+	return typ, true
+}
+
+// bindsVariables reports whether the given struct type, or any struct nested
+// in it, has a field bound to an environment variable. Types in the given set
+// are being visited and are ignored.
+func bindsVariables(typ reflect.Type, o *options, visiting map[reflect.Type]bool) bool {
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	for idx := 0; idx < typ.NumField(); idx++ {
+		sf := typ.Field(idx)
+
+		tags, err := structtag.Parse(string(sf.Tag))
+		if err != nil {
+			continue
 		}
 
-		v, defined := lookup(envTag.Name, defaultValue)
+		if _, err := tags.Get(o.tagNames.Env); err == nil {
+			return true
+		}
 
-		if isRequired && !defined {
-			return fmt.Errorf("%w: environment variable `%s` must be defined", ErrRequiredField, envTag.Name)
+		nested, ok := nestedStructType(sf)
+		if ok && !visiting[nested] && bindsVariables(nested, o, visiting) {
+			return true
 		}
+	}
+
+	return false
+}
+
+// fieldOptions holds the options given within the env tag of a field, such
+// as `env:"TOKEN,required,file"`.
+type fieldOptions struct {
+	required bool
+	notEmpty bool
+	expand   bool
+	file     bool
+	trim     bool
+}
 
-		if notEmpty && v.IsZero() {
-			return fmt.Errorf("%w: environment variable `%s` cannot be empty", ErrEmptyField, envTag.Name)
+// fieldOptionsFor parses the given env tag options. Expansion is enabled for
+// every field by the WithExpansion option.
+func fieldOptionsFor(envOptions []string, o *options) fieldOptions {
+	fo := fieldOptions{expand: o.expand, trim: true}
+
+	for i := range envOptions {
+		switch envOptions[i] {
+		case "required":
+			fo.required = true
+		case "notEmpty":
+			fo.notEmpty = true
+		case "expand":
+			fo.expand = true
+		case "file":
+			fo.file = true
+		case "noTrim":
+			fo.trim = false
 		}
+	}
+
+	return fo
+}
+
+// parseField injects the given environment variable into the given struct
+// field. A FieldError is returned if the field could not be injected.
+func parseField(field reflect.Value, path, varName string, envOptions []string, tags *structtag.Tags, o *options) *FieldError {
+	defaultValue := ""
+	if defaultTag, err := tags.Get(o.tagNames.Default); err == nil {
+		defaultValue = defaultTag.Value()
+	}
+
+	fo := fieldOptionsFor(envOptions, o)
 
-		writeValue, err := valueForField(field, v, tags, envTag.Name)
+	if isSecret(field.Type(), envOptions) {
+		secret := *o
+		secret.secret = true
+		o = &secret
+	}
+
+	v, defined := lookup(o, varName, defaultValue)
+
+	if !defined {
+		content, ok, err := lookupSuffixedFile(varName, fo, o)
 		if err != nil {
-			return err
+			return &FieldError{Field: path, Var: varName, Err: err}
+		}
+
+		if ok {
+			// contents of such files are used as is
+			v, defined = content, true
+			fo.file, fo.expand = false, false
+		}
+	}
+
+	if defined && o.defined != nil {
+		o.defined[path] = true
+	}
+
+	if fo.required && !defined {
+		return &FieldError{
+			Field: path,
+			Var:   varName,
+			Err:   fmt.Errorf("%w: environment variable `%s` must be defined", ErrRequiredField, varName),
+		}
+	}
+
+	if fo.expand {
+		expanded, err := newExpander(o.lookupWithDefaults).expandVar(varName, string(v))
+		if err != nil {
+			return &FieldError{Field: path, Var: varName, Err: err}
+		}
+
+		v = value(expanded)
+	}
+
+	v, err := resolveFileValue(v, varName, fo, o)
+	if err != nil {
+		return &FieldError{Field: path, Var: varName, Err: err}
+	}
+
+	if fo.notEmpty && v.IsZero() {
+		return &FieldError{
+			Field: path,
+			Var:   varName,
+			Err:   fmt.Errorf("%w: environment variable `%s` cannot be empty", ErrEmptyField, varName),
 		}
+	}
 
-		field.Set(reflect.ValueOf(writeValue))
+	writeValue, err := valueForField(field, v, tags, varName, o)
+	if err != nil {
+		return &FieldError{Field: path, Var: varName, Err: err}
 	}
 
+	field.Set(reflect.ValueOf(writeValue).Convert(field.Type()))
+
 	return nil
 }
 
+// lookupSuffixedFile reads the value of the given undefined variable from the
+// file referenced by the variable named after it and the file suffix, such as
+// `DB_PASSWORD_FILE`, if any.
+func lookupSuffixedFile(varName string, fo fieldOptions, o *options) (value, bool, error) {
+	if o.fileSuffix == "" {
+		return "", false, nil
+	}
+
+	filePath, ok := o.lookup(varName + o.fileSuffix)
+	if !ok {
+		return "", false, nil
+	}
+
+	content, err := readValueFile(varName+o.fileSuffix, filePath, fo.trim, o)
+	if err != nil {
+		return "", false, err
+	}
+
+	return value(content), true, nil
+}
+
+// resolveFileValue returns the contents of the file whose path is the given
+// value when the `file` env option is set, or the value itself otherwise.
+func resolveFileValue(v value, varName string, fo fieldOptions, o *options) (value, error) {
+	if !fo.file || v == "" {
+		return v, nil
+	}
+
+	content, err := readValueFile(varName, string(v), fo.trim, o)
+	if err != nil {
+		return "", err
+	}
+
+	return value(content), nil
+}
+
 // MustParse convenience function which calls Parse and panics if an error is returned.
-func MustParse(st interface{}) {
-	if err := Parse(st); err != nil {
+func MustParse(st interface{}, opts ...Option) {
+	if err := Parse(st, opts...); err != nil {
 		panic(err)
 	}
 }
 
 // LoadAndParse convenience function which first loads environment variables
-// and then injects them into the given struct.
+// and then injects them into the given struct. Dotenv files are loaded exactly
+// once per call.
 //
 // This simplifies the following use case:
 //
@@ -214,77 +635,120 @@ func MustParse(st interface{}) {
 //		panic(err)
 //	}
 //
+// Variables are loaded as Load does, unless different loading options such as
+// WithLoadMode or WithFiles are given.
+//
 // See Parse function for more information.
-func LoadAndParse(st interface{}) error {
-	if err := Load(); err != nil {
-		return err
-	}
-
-	return Parse(st)
+func LoadAndParse(st interface{}, opts ...Option) error {
+	return defaultParser.With(legacyLoadOptions()...).With(opts...).LoadAndParse(st)
 }
 
 // MustLoadAndParse convenience function which calls LoadAndParse and panics if an error
 // is returned.
-func MustLoadAndParse(st interface{}) {
-	if err := LoadAndParse(st); err != nil {
+func MustLoadAndParse(st interface{}, opts ...Option) {
+	if err := LoadAndParse(st, opts...); err != nil {
 		panic(err)
 	}
 }
 
-func valueForField(field reflect.Value, value value, tags *structtag.Tags, varName string) (interface{}, error) {
-	fieldType := field.Type()
+func valueForField(field reflect.Value, value value, tags *structtag.Tags, varName string, o *options) (interface{}, error) {
+	return valueForType(field.Type(), value, tags, varName, o)
+}
+
+// valueForType converts the given raw value into a value of the given type.
+func valueForType(typ reflect.Type, value value, tags *structtag.Tags, varName string, o *options) (interface{}, error) {
+	if parse, ok := parserFor(typ, value, reflect.StructTag(tags.String()), o); ok {
+		return convertValue(typ, value, varName, o, parse)
+	}
 
-	if fieldType.AssignableTo(timeType) {
-		timeLayoutTag, gErr := tags.Get("timeLayout")
+	if typ.AssignableTo(timeType) {
+		timeLayoutTag, gErr := tags.Get(o.tagNames.TimeLayout)
 		if gErr != nil {
 			return nil, fmt.Errorf("%w: expecting tag `timeLayout` for environment variables of type `time.Time`", ErrTimeLayoutRequired)
 		}
 
-		return value.AsTime(timeLayoutTag.Name), nil
+		return convertValue(typ, value, varName, o, func() (interface{}, error) {
+			return value.AsTime(timeLayoutTag.Name)
+		})
 	}
 
-	if fieldType.AssignableTo(stringSliceType) {
-		delimiter := ""
-		delimiterTag, gErr := tags.Get("delimiter")
+	if decode, ok := decoderFor(typ, value); ok {
+		return convertValue(typ, value, varName, o, decode)
+	}
 
-		if gErr == nil {
-			delimiter = delimiterTag.Name
-		}
+	if typ.AssignableTo(durationType) {
+		return convertValue(typ, value, varName, o, func() (interface{}, error) {
+			return value.AsDuration()
+		})
+	}
 
-		if delimiter == "" {
-			delimiter = ","
-		}
+	if isByteSlice(typ) {
+		return []byte(value), nil
+	}
 
-		return value.AsStringSlice(delimiter), nil
+	if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		return sliceForType(typ, value, tags, varName, o)
 	}
 
-	if fieldType.AssignableTo(durationType) {
-		return value.AsDuration(), nil
+	if typ.Kind() == reflect.Map {
+		return mapForType(typ, value, tags, varName, o)
 	}
 
-	t, ok := valueMapper[field.Kind()]
+	t, ok := valueMapper[typ.Kind()]
 	if !ok {
-		return nil, fmt.Errorf("unsupported environment data type `%s` for variable `%s`", fieldType.Kind(), varName)
+		return nil, fmt.Errorf("unsupported environment data type `%s` for variable `%s`", typ.Kind(), varName)
 	}
 
-	return t(value), nil
+	return convertValue(typ, value, varName, o, func() (interface{}, error) {
+		return t(value)
+	})
 }
 
-// lookup similar to Get but returns whether the variable is present or not.
-func lookup(name string, def ...string) (value, bool) {
-	if tuples, overridden := isOverriddenCall(); overridden {
-		if v, ok := tuples[name]; ok {
-			return value(v), true
+// isByteSlice reports whether the given type holds raw bytes, such as
+// `[]byte` or `net.IP`. Slices of types merely defined over `uint8` are parsed
+// element by element instead.
+func isByteSlice(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Slice && typ.ConvertibleTo(bytesType)
+}
+
+// convertValue runs the given conversion function, returning the zero value
+// of the given type for empty values. Conversion failures are reported as an
+// InvalidValueError unless lenient conversion was requested.
+func convertValue(typ reflect.Type, v value, varName string, o *options, conv func() (interface{}, error)) (interface{}, error) {
+	if v == "" {
+		return reflect.Zero(typ).Interface(), nil
+	}
+
+	out, err := conv()
+	if err != nil {
+		if o.lenient {
+			return reflect.Zero(typ).Interface(), nil
+		}
+
+		if o.secret {
+			err = &redactedError{err: err, raw: string(v)}
+		}
+
+		return nil, &InvalidValueError{
+			Var:   varName,
+			Type:  typ.String(),
+			Value: o.mask(string(v)),
+			Err:   err,
 		}
 	}
 
+	return out, nil
+}
+
+// lookup similar to Get but returns whether the variable is present or not.
+func lookup(o *options, name string, def ...string) (value, bool) {
 	d := ""
 	if len(def) > 0 {
 		d = def[0]
 	}
 
 	v := value(d)
-	val, defined := os.LookupEnv(name)
+	val, defined := o.lookup(name)
 
 	if defined {
 		v = value(val)
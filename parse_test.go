@@ -2,6 +2,7 @@ package dotenv_test
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
@@ -109,6 +110,309 @@ func TestParse(t *testing.T) {
 	})
 }
 
+func TestParse_InvalidValues(t *testing.T) {
+	t.Run("GIVEN a struct with an int field AND a variable defined with a non-numeric value", func(t *testing.T) {
+		t.Setenv("TEST_INVALID_PORT", "80a")
+
+		t.Run("WHEN parsing THEN an invalid value error is raised", func(t *testing.T) {
+			env := dummyStructInvalid{}
+			err := dotenv.Parse(&env)
+
+			require.ErrorIs(t, err, dotenv.ErrInvalidValue)
+
+			var ive *dotenv.InvalidValueError
+
+			require.ErrorAs(t, err, &ive)
+			require.Equal(t, "TEST_INVALID_PORT", ive.Var)
+			require.Equal(t, "int", ive.Type)
+			require.Equal(t, "80a", ive.Value)
+		})
+
+		t.Run("WHEN parsing with lenient conversion THEN the zero value is written", func(t *testing.T) {
+			env := dummyStructInvalid{Port: 8080}
+
+			require.NoError(t, dotenv.Parse(&env, dotenv.WithLenientConversion()))
+			require.Zero(t, env.Port)
+		})
+	})
+
+	t.Run("GIVEN a struct with an int field AND a variable defined with an empty value", func(t *testing.T) {
+		t.Setenv("TEST_INVALID_PORT", "")
+
+		t.Run("WHEN parsing THEN the zero value is written", func(t *testing.T) {
+			env := dummyStructInvalid{Port: 8080}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Zero(t, env.Port)
+		})
+	})
+}
+
+func TestParse_AggregatedErrors(t *testing.T) {
+	t.Run("GIVEN a struct with several failing fields", func(t *testing.T) {
+		t.Setenv("TEST_AGG_EMPTY", "")
+		t.Setenv("TEST_AGG_INT", "abc")
+
+		t.Run("WHEN parsing THEN every failing field is reported", func(t *testing.T) {
+			env := dummyStructAggregated{}
+			err := dotenv.Parse(&env)
+
+			require.ErrorIs(t, err, dotenv.ErrRequiredField)
+			require.ErrorIs(t, err, dotenv.ErrEmptyField)
+			require.ErrorIs(t, err, dotenv.ErrInvalidValue)
+
+			var verrs dotenv.ValidationErrors
+
+			require.ErrorAs(t, err, &verrs)
+			require.Len(t, verrs, 3)
+
+			require.Equal(t, "Required", verrs[0].Field)
+			require.Equal(t, "TEST_AGG_REQUIRED", verrs[0].Var)
+			require.Equal(t, "Empty", verrs[1].Field)
+			require.Equal(t, "TEST_AGG_EMPTY", verrs[1].Var)
+			require.Equal(t, "Int", verrs[2].Field)
+			require.Equal(t, "TEST_AGG_INT", verrs[2].Var)
+		})
+	})
+}
+
+func TestParse_NestedStructs(t *testing.T) {
+	t.Run("GIVEN a struct with nested, pointer and embedded structs AND prefixed variables", func(t *testing.T) {
+		t.Setenv("TEST_NESTED_NAME", "app")
+		t.Setenv("TEST_NESTED_DB_HOST", "db.local")
+		t.Setenv("TEST_NESTED_DB_PORT", "5432")
+		t.Setenv("TEST_NESTED_REDIS_HOST", "redis.local")
+		t.Setenv("TEST_NESTED_REDIS_TLS_ENABLED", "true")
+		t.Setenv("TEST_NESTED_EMBEDDED", "embedded")
+
+		t.Run("WHEN parsing THEN nested fields are populated", func(t *testing.T) {
+			env := dummyNested{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, "app", env.Name)
+			require.Equal(t, "db.local", env.DB.Host)
+			require.Equal(t, 5432, env.DB.Port)
+			require.NotNil(t, env.Redis)
+			require.Equal(t, "redis.local", env.Redis.Host)
+			require.True(t, env.Redis.TLS.Enabled)
+			require.Equal(t, "embedded", env.Embedded)
+		})
+	})
+
+	t.Run("GIVEN a struct with a nested invalid variable", func(t *testing.T) {
+		t.Setenv("TEST_NESTED_DB_PORT", "abc")
+
+		t.Run("WHEN parsing THEN the error reports the field path", func(t *testing.T) {
+			env := dummyNested{}
+			err := dotenv.Parse(&env)
+
+			var verrs dotenv.ValidationErrors
+
+			require.ErrorAs(t, err, &verrs)
+			require.Len(t, verrs, 1)
+			require.Equal(t, "DB.Port", verrs[0].Field)
+			require.Equal(t, "TEST_NESTED_DB_PORT", verrs[0].Var)
+		})
+	})
+
+	t.Run("GIVEN a struct with an untagged pointer to a struct binding no variable", func(t *testing.T) {
+		t.Setenv("TEST_NESTED_NAME", "app")
+
+		t.Run("WHEN parsing THEN the pointer is left nil", func(t *testing.T) {
+			env := struct {
+				Name   string `env:"TEST_NESTED_NAME"`
+				Client *http.Client
+			}{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, "app", env.Name)
+			require.Nil(t, env.Client)
+		})
+	})
+
+	t.Run("GIVEN a struct with an untagged pointer to a struct binding variables", func(t *testing.T) {
+		t.Setenv("TEST_NESTED_HOST", "redis.local")
+
+		t.Run("WHEN parsing with a nil pointer THEN it is allocated and populated", func(t *testing.T) {
+			env := dummyUntaggedPointer{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.NotNil(t, env.Redis)
+			require.Equal(t, "redis.local", env.Redis.Host)
+		})
+
+		t.Run("WHEN parsing with a preallocated pointer THEN it is populated", func(t *testing.T) {
+			redis := &dummyCache{}
+			env := dummyUntaggedPointer{Redis: redis}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Same(t, redis, env.Redis)
+			require.Equal(t, "redis.local", env.Redis.Host)
+		})
+	})
+
+	t.Run("GIVEN a self-referential struct", func(t *testing.T) {
+		t.Setenv("TEST_NESTED_NODE_NAME", "root")
+
+		t.Run("WHEN parsing THEN it is populated once and parsing returns", func(t *testing.T) {
+			env := dummyNode{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, "root", env.Name)
+			require.Nil(t, env.Next)
+			require.Nil(t, env.Prev)
+		})
+	})
+}
+
+type dummyUntaggedPointer struct {
+	Redis *dummyCache
+}
+
+type dummyCache struct {
+	Host string `env:"TEST_NESTED_HOST"`
+}
+
+type dummyNode struct {
+	Name string     `env:"TEST_NESTED_NODE_NAME"`
+	Next *dummyNode `envPrefix:"NEXT_"`
+	Prev *dummyNode
+}
+
+func TestParse_Expansion(t *testing.T) {
+	t.Run("GIVEN a struct with expandable fields AND referenced variables defined", func(t *testing.T) {
+		t.Setenv("TEST_EXPAND_USER", "admin")
+		t.Setenv("TEST_EXPAND_RAW", "${TEST_EXPAND_USER}")
+
+		t.Run("WHEN parsing THEN only expandable values and defaults are expanded", func(t *testing.T) {
+			env := dummyExpansion{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, "postgres://admin@localhost/app", env.URL)
+			require.Equal(t, "${TEST_EXPAND_USER}", env.Raw)
+		})
+
+		t.Run("WHEN parsing with expansion enabled for every field THEN every value is expanded", func(t *testing.T) {
+			env := dummyExpansion{}
+
+			require.NoError(t, dotenv.Parse(&env, dotenv.WithExpansion()))
+			require.Equal(t, "admin", env.Raw)
+		})
+
+		t.Run("WHEN parsing within an override THEN overridden values are used for expansion", func(t *testing.T) {
+			env := dummyExpansion{}
+
+			dotenv.WithOverride(func() {
+				require.NoError(t, dotenv.Parse(&env))
+			}, "TEST_EXPAND_HOST", "db.local")
+
+			require.Equal(t, "postgres://admin@db.local/app", env.URL)
+		})
+
+		t.Run("WHEN a required reference is missing THEN an expansion error is raised", func(t *testing.T) {
+			env := dummyExpansion{}
+
+			dotenv.WithOverride(func() {
+				require.ErrorIs(t, dotenv.Parse(&env), dotenv.ErrExpansion)
+			}, "TEST_EXPAND_USER", dotenv.Unset)
+		})
+	})
+}
+
+func TestParse_Defaults(t *testing.T) {
+	t.Run("GIVEN a struct with defaults referencing other fields AND a Defaulter implementation", func(t *testing.T) {
+		t.Run("WHEN parsing with no variable defined THEN defaults are resolved from other fields' defaults", func(t *testing.T) {
+			env := dummyDefaults{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, 8080, env.HTTPPort)
+			require.Equal(t, "localhost:8080", env.Addr)
+			require.Equal(t, 8081, env.MetricsPort)
+			require.Equal(t, "http://localhost:8080/health", env.Nested.HealthURL)
+			require.True(t, env.Nested.Defaulted)
+		})
+
+		t.Run("WHEN parsing THEN defaults of fields not requesting expansion are kept as is", func(t *testing.T) {
+			env := dummyDefaults{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, "${TEST_DEFAULTS_HTTP_PORT}", env.Template)
+		})
+
+		t.Run("WHEN parsing with expansion enabled for every field THEN every default is expanded", func(t *testing.T) {
+			env := dummyDefaults{}
+
+			require.NoError(t, dotenv.Parse(&env, dotenv.WithExpansion()))
+			require.Equal(t, "8080", env.Template)
+		})
+
+		t.Run("WHEN parsing with a referenced variable defined THEN defaults are resolved from such variable", func(t *testing.T) {
+			t.Setenv("TEST_DEFAULTS_HTTP_PORT", "9090")
+
+			env := dummyDefaults{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, 9090, env.HTTPPort)
+			require.Equal(t, "localhost:9090", env.Addr)
+			require.Equal(t, 9091, env.MetricsPort)
+		})
+
+		t.Run("WHEN parsing with the derived variable defined THEN the Defaulter keeps it", func(t *testing.T) {
+			t.Setenv("TEST_DEFAULTS_METRICS_PORT", "7000")
+
+			env := dummyDefaults{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, 7000, env.MetricsPort)
+		})
+	})
+
+	t.Run("GIVEN a struct embedding a Defaulter implementation", func(t *testing.T) {
+		t.Run("WHEN parsing THEN the promoted SetDefaults is called once", func(t *testing.T) {
+			env := dummyEmbeddingDefaults{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, 8080, env.HTTPPort)
+			require.Equal(t, 8081, env.MetricsPort)
+			require.Equal(t, 1, env.Calls)
+		})
+
+		t.Run("WHEN the embedded Defaulter is a nil pointer binding no variable THEN it is allocated and called once", func(t *testing.T) {
+			env := struct {
+				*DummyCountingDefaults
+			}{}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.NotNil(t, env.DummyCountingDefaults)
+			require.Equal(t, 1, env.Calls)
+		})
+	})
+}
+
+type dummyBaseDefaults struct {
+	HTTPPort    int `env:"TEST_DEFAULTS_BASE_HTTP_PORT" default:"8080"`
+	MetricsPort int `env:"TEST_DEFAULTS_BASE_METRICS_PORT"`
+	Calls       int
+}
+
+func (d *dummyBaseDefaults) SetDefaults() {
+	d.Calls++
+	d.MetricsPort += d.HTTPPort + 1
+}
+
+type dummyEmbeddingDefaults struct {
+	dummyBaseDefaults
+}
+
+// DummyCountingDefaults is exported so that pointers embedding it can be set.
+type DummyCountingDefaults struct {
+	Calls int
+}
+
+func (d *DummyCountingDefaults) SetDefaults() {
+	d.Calls++
+}
+
 func TestMustParse(t *testing.T) {
 	t.Run("GIVEN a struct with notEmpty variable and one variable defined but with no value", func(t *testing.T) {
 		env := dummyStructNotEmpty{}
@@ -148,6 +452,69 @@ type dummyStructNotEmpty struct {
 	String string `env:"TEST_NOT_EMPTY,notEmpty"`
 }
 
+type dummyStructInvalid struct {
+	Port int `env:"TEST_INVALID_PORT"`
+}
+
+type dummyStructAggregated struct {
+	Required string `env:"TEST_AGG_REQUIRED,required"`
+	Empty    string `env:"TEST_AGG_EMPTY,notEmpty"`
+	Int      int    `env:"TEST_AGG_INT"`
+	Valid    string `env:"TEST_AGG_VALID" default:"ok"`
+}
+
+type dummyDB struct {
+	Host string `env:"HOST"`
+	Port int    `env:"PORT"`
+}
+
+type dummyRedis struct {
+	Host string `env:"HOST"`
+	TLS  struct {
+		Enabled bool `env:"ENABLED"`
+	} `envPrefix:"TLS_"`
+}
+
+type dummyEmbedded struct {
+	Embedded string `env:"TEST_NESTED_EMBEDDED"`
+}
+
+type dummyNested struct {
+	dummyEmbedded
+
+	Name  string      `env:"TEST_NESTED_NAME"`
+	DB    dummyDB     `envPrefix:"TEST_NESTED_DB_"`
+	Redis *dummyRedis `envPrefix:"TEST_NESTED_REDIS_"`
+}
+
+type dummyExpansion struct {
+	URL string `env:"TEST_EXPAND_URL,expand" default:"postgres://${TEST_EXPAND_USER:?is required}@${TEST_EXPAND_HOST:-localhost}/app"`
+	Raw string `env:"TEST_EXPAND_RAW"`
+}
+
+type dummyDefaults struct {
+	HTTPPort    int    `env:"TEST_DEFAULTS_HTTP_PORT" default:"8080"`
+	Addr        string `env:"TEST_DEFAULTS_ADDR,expand" default:"localhost:${TEST_DEFAULTS_HTTP_PORT}"`
+	Template    string `env:"TEST_DEFAULTS_TEMPLATE" default:"${TEST_DEFAULTS_HTTP_PORT}"`
+	MetricsPort int    `env:"TEST_DEFAULTS_METRICS_PORT"`
+	Nested      dummyNestedDefaults
+}
+
+func (d *dummyDefaults) SetDefaults() {
+	if d.MetricsPort == 0 {
+		d.MetricsPort = d.HTTPPort + 1
+	}
+}
+
+type dummyNestedDefaults struct {
+	HealthURL string `env:"TEST_DEFAULTS_HEALTH_URL,expand" default:"http://${TEST_DEFAULTS_ADDR}/health"`
+	Defaulted bool
+}
+
+func (d *dummyNestedDefaults) SetDefaults() {
+	d.Defaulted = true
+}
+
 type dummyStringSlice struct {
 	StringSlice             []string `env:"TEST_STRING_SLICE" delimiter:";"`
 	StringSliceWithDefaults []string `env:"TEST_STRING_SLICE_WITH_DEFAULTS" delimiter:";" default:"X;Y;Z"`
@@ -0,0 +1,147 @@
+package dotenv
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Parser injects environment variables into structs.
+//
+// Each Parser owns its sources, registered type parsers, tag names, loading
+// rules and hooks, so different libraries within the same binary can
+// configure the injection of variables independently. Package level functions
+// such as Parse or Load delegate to a default Parser.
+type Parser struct {
+	opts options
+}
+
+// defaultParser is the Parser used by package level functions.
+var defaultParser = &Parser{
+	opts: options{
+		tagNames:    defaultTagNames,
+		fileSuffix:  defaultFileSuffix,
+		maxFileSize: defaultMaxFileSize,
+		registry:    globalParsers,
+		memory:      memoryEnv,
+	},
+}
+
+// New builds a new Parser configured with the given options.
+//
+// Unlike the default Parser, the returned one does not use the parsers
+// registered by RegisterParser, nor the variables loaded in memory by Load,
+// as it holds its own.
+func New(opts ...Option) *Parser {
+	p := &Parser{
+		opts: options{
+			tagNames:    defaultTagNames,
+			fileSuffix:  defaultFileSuffix,
+			maxFileSize: defaultMaxFileSize,
+			registry: &parserRegistry{
+				parsers: make(map[reflect.Type]ParserFunc),
+			},
+			memory: &memoryStore{
+				values: make(map[string]string),
+				loaded: make(map[string]loadedVar),
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&p.opts)
+	}
+
+	return p
+}
+
+// With returns a copy of this Parser with the given options applied on top of
+// its own. The copy shares the registered type parsers and the variables
+// loaded in memory with this Parser.
+func (p *Parser) With(opts ...Option) *Parser {
+	c := &Parser{opts: p.opts}
+
+	for _, opt := range opts {
+		opt(&c.opts)
+	}
+
+	return c
+}
+
+// RegisterParser registers a parser function for the given type within this
+// Parser. See RegisterParser function for more information.
+func (p *Parser) RegisterParser(typ reflect.Type, fn ParserFunc) {
+	p.opts.registry.set(typ, fn)
+}
+
+// Parse injects environment variables into the given struct. See Parse
+// function for more information.
+func (p *Parser) Parse(st interface{}) error {
+	o := p.opts
+
+	val := reflect.ValueOf(st)
+	if val.Kind() != reflect.Ptr {
+		return fmt.Errorf("%w: given `%s` is not a pointer", ErrNotAPointer, val.Kind())
+	}
+
+	val = val.Elem()
+
+	if val.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: given `%s` is not a pointer", ErrNotAPointer, val.Kind())
+	}
+
+	o.defaults = make(map[string]string)
+	o.defined = make(map[string]bool)
+	collectDefaults(val.Type(), "", &o, o.defaults, make(map[reflect.Type]bool))
+
+	errs := parseStruct(val, &o)
+	recordProvenance(val, &o)
+
+	failed := make(map[string]bool, len(errs))
+
+	for i := range errs {
+		failed[errs[i].Field] = true
+	}
+
+	errs = append(errs, validateStruct(val, &o, failed)...)
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	for _, hook := range o.hooks {
+		if err := hook(st); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MustParse calls Parse and panics if an error is returned.
+func (p *Parser) MustParse(st interface{}) {
+	if err := p.Parse(st); err != nil {
+		panic(err)
+	}
+}
+
+// Load loads the environment from dotenv files according to the loading
+// options of this Parser. See LoadWithOptions function for more information.
+func (p *Parser) Load() error {
+	return load(&p.opts)
+}
+
+// LoadAndParse calls Load and then Parse.
+func (p *Parser) LoadAndParse(st interface{}) error {
+	if err := p.Load(); err != nil {
+		return err
+	}
+
+	return p.Parse(st)
+}
+
+// MustLoadAndParse calls LoadAndParse and panics if an error is returned.
+func (p *Parser) MustLoadAndParse(st interface{}) {
+	if err := p.LoadAndParse(st); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,97 @@
+package dotenv_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tangelo-labs/go-dotenv"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("GIVEN two parsers with different sources, tag names and registered parsers", func(t *testing.T) {
+		upper := dotenv.New(
+			dotenv.WithSources(dotenv.MapSource(map[string]string{"NAME": "upper"})),
+			dotenv.WithTagNames(dotenv.TagNames{Env: "config"}),
+		)
+		upper.RegisterParser(reflect.TypeOf(""), func(raw string, _ reflect.StructTag) (interface{}, error) {
+			return strings.ToUpper(raw), nil
+		})
+
+		plain := dotenv.New(dotenv.WithSources(dotenv.MapSource(map[string]string{"NAME": "plain"})))
+
+		t.Run("WHEN parsing with each parser THEN each one uses its own configuration", func(t *testing.T) {
+			var upperEnv struct {
+				Name string `config:"NAME"`
+			}
+
+			var plainEnv struct {
+				Name string `env:"NAME"`
+			}
+
+			require.NoError(t, upper.Parse(&upperEnv))
+			require.NoError(t, plain.Parse(&plainEnv))
+			require.Equal(t, "UPPER", upperEnv.Name)
+			require.Equal(t, "plain", plainEnv.Name)
+		})
+
+		t.Run("WHEN parsing with the package functions THEN the default configuration is used", func(t *testing.T) {
+			t.Setenv("TEST_PARSER_NAME", "default")
+
+			var env struct {
+				Name string `env:"TEST_PARSER_NAME"`
+			}
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Equal(t, "default", env.Name)
+		})
+	})
+
+	t.Run("GIVEN a parser with a failing hook", func(t *testing.T) {
+		errHook := errors.New("hook failed")
+		called := false
+
+		p := dotenv.New(
+			dotenv.WithSources(dotenv.MapSource(map[string]string{"NAME": "hooked"})),
+			dotenv.WithHook(func(st interface{}) error {
+				called = true
+
+				return errHook
+			}),
+		)
+
+		t.Run("WHEN parsing THEN the hook is invoked AND its error is returned", func(t *testing.T) {
+			var env struct {
+				Name string `env:"NAME"`
+			}
+
+			require.ErrorIs(t, p.Parse(&env), errHook)
+			require.True(t, called)
+			require.Equal(t, "hooked", env.Name)
+		})
+	})
+
+	t.Run("GIVEN a parser loading a .env file in memory", func(t *testing.T) {
+		dir := writeTemp(t, map[string]string{
+			".env": "TEST_PARSER_MEMORY=memory\n",
+		})
+
+		p := dotenv.New(dotenv.WithSearchDir(dir), dotenv.WithLoadMode(dotenv.LoadModeInMemory))
+
+		t.Run("WHEN loading and parsing THEN loaded values are only visible to such parser", func(t *testing.T) {
+			var env struct {
+				Memory string `env:"TEST_PARSER_MEMORY"`
+			}
+
+			require.NoError(t, p.LoadAndParse(&env))
+			require.Equal(t, "memory", env.Memory)
+
+			env.Memory = ""
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Empty(t, env.Memory)
+		})
+	})
+}
@@ -0,0 +1,138 @@
+package dotenv
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/fatih/structtag"
+)
+
+// Provenance describes where the value of a struct field was resolved from.
+type Provenance struct {
+	// Origin is the kind of source the value comes from.
+	Origin Origin
+
+	// Var is the name of the variable actually read, such as `DB_PASSWORD`,
+	// or `DB_PASSWORD_FILE` when the value was read from the file it points
+	// to.
+	Var string
+
+	// File is the path of the file the value was read from, if any: a dotenv
+	// file read by Load or FileSource, a file read by DirSource, or the file
+	// pointed to by a `_FILE` variable.
+	File string
+
+	// Line is the line of the dotenv file where the variable is defined, or
+	// zero when unknown or when the whole file is the value.
+	Line int
+}
+
+// String renders the provenance as the `path:line` position of the
+// definition of the value when read from a file, or as its origin otherwise.
+func (p Provenance) String() string {
+	if p.File == "" {
+		return string(p.Origin)
+	}
+
+	if p.Line == 0 {
+		return p.File
+	}
+
+	return fmt.Sprintf("%s:%d", p.File, p.Line)
+}
+
+// WithProvenance records in the given map, keyed by field path such as
+// `DB.Port`, the provenance of the value of every field bound to an
+// environment variable. The map is populated by Parse even when it fails.
+//
+// Lines of dotenv files are only known for files read by Load or FileSource.
+func WithProvenance(record map[string]Provenance) Option {
+	return func(o *options) {
+		o.provenance = record
+	}
+}
+
+// recordProvenance records the provenance of every field of the given struct
+// value in the map given to WithProvenance, if any.
+func recordProvenance(val reflect.Value, o *options) {
+	if o.provenance == nil {
+		return
+	}
+
+	ro := *o
+	ro.readOnly = true
+
+	walkStruct(val, "", "", &ro,
+		func(field reflect.Value, path, varName string, _ []string, tags *structtag.Tags) *FieldError {
+			o.provenance[path] = provenanceOf(field, varName, tags, o)
+
+			return nil
+		},
+		func(reflect.Value, string, reflect.Type) *FieldError {
+			return nil
+		},
+	)
+}
+
+// provenanceOf finds where the value of the given field, bound to the given
+// variable, comes from. Fields of structs behind nil pointers, which are not
+// addressable on read-only walks, are reported as unset.
+func provenanceOf(field reflect.Value, varName string, tags *structtag.Tags, o *options) Provenance {
+	if !field.CanAddr() {
+		return Provenance{Origin: OriginUnset, Var: varName}
+	}
+
+	if p, ok := o.provenanceOf(varName); ok {
+		return p
+	}
+
+	if o.fileSuffix != "" {
+		if path, ok := o.lookup(varName + o.fileSuffix); ok {
+			return Provenance{Origin: OriginFile, Var: varName + o.fileSuffix, File: path}
+		}
+	}
+
+	if _, err := tags.Get(o.tagNames.Default); err == nil || !field.IsZero() {
+		return Provenance{Origin: OriginDefault, Var: varName}
+	}
+
+	return Provenance{Origin: OriginUnset, Var: varName}
+}
+
+// provenanceOf is like lookup, but returns where the given variable is
+// defined.
+func (o *options) provenanceOf(key string) (Provenance, bool) {
+	if v, ok := o.ctxOverrides[key]; ok {
+		return Provenance{Origin: OriginOverride, Var: key}, v != Unset
+	}
+
+	sources := o.sources
+	if sources == nil {
+		sources = o.defaultSources()
+	}
+
+	v, src, ok := findSource(sources, key)
+	if !ok {
+		return Provenance{}, false
+	}
+
+	p := Provenance{Origin: OriginSource, Var: key}
+
+	switch s := src.(type) {
+	case overrideSource:
+		p.Origin = OriginOverride
+	case fileSource:
+		p.Origin, p.File, p.Line = OriginFile, s.locations[key].path, s.locations[key].line
+	case *memoryStore:
+		loc, _ := s.loadedFrom(key, v)
+		p.Origin, p.File, p.Line = OriginFile, loc.path, loc.line
+	case envSource:
+		p.Origin = OriginEnv
+
+		if loc, loaded := o.memory.loadedFrom(key, v); loaded {
+			p.Origin, p.File, p.Line = OriginFile, loc.path, loc.line
+		}
+	}
+
+	return p, true
+}
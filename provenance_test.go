@@ -0,0 +1,89 @@
+package dotenv_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tangelo-labs/go-dotenv"
+)
+
+func TestWithProvenance(t *testing.T) {
+	t.Run("GIVEN variables defined in the environment, a dotenv file, a secret file and an override", func(t *testing.T) {
+		dir := writeTemp(t, map[string]string{
+			".env":         "# database\nTEST_PROVENANCE_HOST=db.local\n\nexport TEST_PROVENANCE_PORT=5432\n",
+			"secrets/pass": "s3cr3t",
+		})
+
+		t.Setenv("TEST_PROVENANCE_USER", "admin")
+		t.Setenv("TEST_PROVENANCE_PASSWORD_FILE", filepath.Join(dir, "secrets", "pass"))
+		dotenv.OverrideT(t, "TEST_PROVENANCE_LEVEL", "debug")
+		unsetAfter(t, "TEST_PROVENANCE_HOST", "TEST_PROVENANCE_PORT")
+
+		require.NoError(t, dotenv.LoadWithOptions(dotenv.WithSearchDir(dir), dotenv.WithFiles(".env")))
+
+		record := make(map[string]dotenv.Provenance)
+
+		var cfg dummyProvenance
+
+		require.NoError(t, dotenv.Parse(&cfg, dotenv.WithProvenance(record)))
+
+		t.Run("WHEN parsing with provenance THEN the origin of every field is recorded", func(t *testing.T) {
+			envFile := filepath.Join(dir, ".env")
+
+			require.Equal(t, map[string]dotenv.Provenance{
+				"Host":     {Origin: dotenv.OriginFile, Var: "TEST_PROVENANCE_HOST", File: envFile, Line: 2},
+				"Port":     {Origin: dotenv.OriginFile, Var: "TEST_PROVENANCE_PORT", File: envFile, Line: 4},
+				"User":     {Origin: dotenv.OriginEnv, Var: "TEST_PROVENANCE_USER"},
+				"Password": {Origin: dotenv.OriginFile, Var: "TEST_PROVENANCE_PASSWORD_FILE", File: filepath.Join(dir, "secrets", "pass")},
+				"Level":    {Origin: dotenv.OriginOverride, Var: "TEST_PROVENANCE_LEVEL"},
+				"Timeout":  {Origin: dotenv.OriginDefault, Var: "TEST_PROVENANCE_TIMEOUT"},
+				"Token":    {Origin: dotenv.OriginUnset, Var: "TEST_PROVENANCE_TOKEN"},
+			}, record)
+
+			require.Equal(t, envFile+":2", record["Host"].String())
+			require.Equal(t, "env", record["User"].String())
+		})
+	})
+
+	t.Run("GIVEN an invalid value defined in a dotenv file", func(t *testing.T) {
+		dir := writeTemp(t, map[string]string{
+			"app.env": "TEST_PROVENANCE_HOST=db.local\nTEST_PROVENANCE_PORT=abc\n",
+		})
+
+		src, err := dotenv.FileSource(filepath.Join(dir, "app.env"))
+		require.NoError(t, err)
+
+		t.Run("WHEN parsing it THEN the error reports the position of the definition", func(t *testing.T) {
+			var cfg struct {
+				Port int `env:"TEST_PROVENANCE_PORT"`
+			}
+
+			err := dotenv.ParseFrom(&cfg, src)
+			require.Error(t, err)
+
+			require.Contains(t, err.Error(), "field `Port` at "+filepath.Join(dir, "app.env")+":2: ")
+
+			var fieldErr *dotenv.FieldError
+
+			require.True(t, errors.As(err, &fieldErr))
+			require.Equal(t, &dotenv.Provenance{
+				Origin: dotenv.OriginFile,
+				Var:    "TEST_PROVENANCE_PORT",
+				File:   filepath.Join(dir, "app.env"),
+				Line:   2,
+			}, fieldErr.Provenance)
+		})
+	})
+}
+
+type dummyProvenance struct {
+	Host     string        `env:"TEST_PROVENANCE_HOST"`
+	Port     int           `env:"TEST_PROVENANCE_PORT"`
+	User     string        `env:"TEST_PROVENANCE_USER"`
+	Password dotenv.Secret `env:"TEST_PROVENANCE_PASSWORD"`
+	Level    string        `env:"TEST_PROVENANCE_LEVEL"`
+	Timeout  int           `env:"TEST_PROVENANCE_TIMEOUT" default:"30"`
+	Token    string        `env:"TEST_PROVENANCE_TOKEN"`
+}
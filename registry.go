@@ -0,0 +1,123 @@
+package dotenv
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ParserFunc converts the raw value of an environment variable into a value
+// of a specific type.
+//
+// The struct tag of the field being injected is given so parsers can be
+// configured through custom tags.
+type ParserFunc func(raw string, tag reflect.StructTag) (interface{}, error)
+
+// parserRegistry holds parser functions indexed by the type they produce.
+type parserRegistry struct {
+	mu      sync.RWMutex
+	parsers map[reflect.Type]ParserFunc
+}
+
+// globalParsers is the registry of the default Parser, populated by
+// RegisterParser.
+var globalParsers = &parserRegistry{
+	parsers: make(map[reflect.Type]ParserFunc),
+}
+
+// RegisterParser registers a parser function for the given type, which will
+// be used by every subsequent Parse call to inject fields of such type.
+//
+// Registered parsers take precedence over any built-in conversion, so they
+// can be used to support third-party types that cannot implement the Decoder
+// interface. Registering a parser for an already registered type replaces
+// the previous one.
+//
+// Typical usage example:
+//
+//	func init() {
+//		dotenv.RegisterParser(reflect.TypeOf(big.Int{}), func(raw string, _ reflect.StructTag) (interface{}, error) {
+//			i, ok := new(big.Int).SetString(raw, 10)
+//			if !ok {
+//				return nil, errors.New("invalid big integer")
+//			}
+//
+//			return *i, nil
+//		})
+//	}
+func RegisterParser(typ reflect.Type, fn ParserFunc) {
+	defaultParser.RegisterParser(typ, fn)
+}
+
+// WithParser registers a parser function for the given type which is only
+// used by the Parser or Parse call receiving this option. It takes precedence
+// over parsers registered using RegisterParser.
+func WithParser(typ reflect.Type, fn ParserFunc) Option {
+	return func(o *options) {
+		parsers := make(map[reflect.Type]ParserFunc, len(o.parsers)+1)
+
+		for k, v := range o.parsers {
+			parsers[k] = v
+		}
+
+		parsers[typ] = fn
+		o.parsers = parsers
+	}
+}
+
+// set registers the given parser function for the given type.
+func (r *parserRegistry) set(typ reflect.Type, fn ParserFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.parsers[typ] = fn
+}
+
+// get returns the parser function registered for the given type, if any.
+func (r *parserRegistry) get(typ reflect.Type) (ParserFunc, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fn, ok := r.parsers[typ]
+
+	return fn, ok
+}
+
+// parserFor returns a conversion function for the given type if a parser was
+// registered for it, either using the given options or the registry of the
+// Parser they belong to.
+func parserFor(typ reflect.Type, v value, tag reflect.StructTag, o *options) (func() (interface{}, error), bool) {
+	fn, ok := o.parsers[typ]
+	if !ok {
+		fn, ok = o.registry.get(typ)
+	}
+
+	if !ok {
+		return nil, false
+	}
+
+	return func() (interface{}, error) {
+		out, err := fn(string(v), tag)
+		if err != nil {
+			return nil, err
+		}
+
+		if out == nil || !compatibleTypes(reflect.TypeOf(out), typ) {
+			return nil, fmt.Errorf("parser for type `%s` returned a value of type `%T`", typ, out)
+		}
+
+		return out, nil
+	}, true
+}
+
+// compatibleTypes reports whether values of the given type can be stored in
+// fields of the given target type without any lossy conversion: the type is
+// either assignable to the target type, or both share the same underlying
+// type, such as `string` and `type Level string`.
+func compatibleTypes(typ, target reflect.Type) bool {
+	if typ.AssignableTo(target) {
+		return true
+	}
+
+	return typ.Kind() == target.Kind() && typ.ConvertibleTo(target)
+}
@@ -0,0 +1,114 @@
+package dotenv_test
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tangelo-labs/go-dotenv"
+)
+
+func TestRegisterParser(t *testing.T) {
+	t.Run("GIVEN a parser registered for a third-party type AND a variable defined for such type", func(t *testing.T) {
+		dotenv.RegisterParser(reflect.TypeOf(big.Int{}), parseBigInt)
+		t.Setenv("TEST_REGISTRY_BIG", "123456789012345678901234567890")
+
+		t.Run("WHEN parsing THEN the registered parser is used", func(t *testing.T) {
+			env := dummyRegistry{}
+			expected, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+			require.NoError(t, dotenv.Parse(&env))
+			require.Zero(t, expected.Cmp(&env.Big))
+		})
+
+		t.Run("WHEN parsing with a per-call parser for the same type THEN the per-call parser takes precedence", func(t *testing.T) {
+			env := dummyRegistry{}
+			opt := dotenv.WithParser(reflect.TypeOf(big.Int{}), func(raw string, tag reflect.StructTag) (interface{}, error) {
+				require.Equal(t, "hex", tag.Get("base"))
+
+				return *big.NewInt(int64(len(raw))), nil
+			})
+
+			require.NoError(t, dotenv.Parse(&env, opt))
+			require.Zero(t, big.NewInt(30).Cmp(&env.Big))
+		})
+
+		t.Run("WHEN parsing with a per-call parser for a built-in type THEN it takes precedence over built-in conversion", func(t *testing.T) {
+			t.Setenv("TEST_REGISTRY_UPPER", "hello")
+
+			env := dummyRegistry{}
+			opt := dotenv.WithParser(reflect.TypeOf(""), func(raw string, _ reflect.StructTag) (interface{}, error) {
+				return strings.ToUpper(raw), nil
+			})
+
+			require.NoError(t, dotenv.Parse(&env, opt))
+			require.Equal(t, "HELLO", env.Upper)
+		})
+
+		t.Run("WHEN the registered parser returns a value of another type THEN an invalid value error is raised", func(t *testing.T) {
+			env := dummyRegistry{}
+			opt := dotenv.WithParser(reflect.TypeOf(big.Int{}), func(string, reflect.StructTag) (interface{}, error) {
+				return 42, nil
+			})
+
+			require.ErrorIs(t, dotenv.Parse(&env, opt), dotenv.ErrInvalidValue)
+		})
+
+		t.Run("WHEN a parser returns a value only convertible to the field type THEN an invalid value error is raised", func(t *testing.T) {
+			t.Setenv("TEST_REGISTRY_UPPER", "hello")
+			t.Setenv("TEST_REGISTRY_TRIPLE", "1,2,3")
+
+			env := dummyRegistry{}
+			err := dotenv.Parse(&env,
+				dotenv.WithParser(reflect.TypeOf(""), func(string, reflect.StructTag) (interface{}, error) {
+					return 65, nil
+				}),
+				dotenv.WithParser(reflect.TypeOf([3]int{}), func(string, reflect.StructTag) (interface{}, error) {
+					return []int{1, 2}, nil
+				}),
+			)
+
+			var verrs dotenv.ValidationErrors
+
+			require.ErrorAs(t, err, &verrs)
+			require.Len(t, verrs, 2)
+			require.ErrorIs(t, err, dotenv.ErrInvalidValue)
+			require.Contains(t, err.Error(), "returned a value of type `int`")
+			require.Contains(t, err.Error(), "returned a value of type `[]int`")
+			require.Equal(t, "", env.Upper)
+		})
+
+		t.Run("WHEN a parser returns a value sharing the underlying type of the field THEN it is accepted", func(t *testing.T) {
+			t.Setenv("TEST_REGISTRY_LEVEL", "debug")
+
+			env := dummyRegistry{}
+			opt := dotenv.WithParser(reflect.TypeOf(dummyLevel("")), func(raw string, _ reflect.StructTag) (interface{}, error) {
+				return strings.ToUpper(raw), nil
+			})
+
+			require.NoError(t, dotenv.Parse(&env, opt))
+			require.Equal(t, dummyLevel("DEBUG"), env.Level)
+		})
+	})
+}
+
+func parseBigInt(raw string, _ reflect.StructTag) (interface{}, error) {
+	i, ok := new(big.Int).SetString(raw, 10)
+	if !ok {
+		return nil, errors.New("invalid big integer")
+	}
+
+	return *i, nil
+}
+
+type dummyLevel string
+
+type dummyRegistry struct {
+	Big    big.Int    `env:"TEST_REGISTRY_BIG" base:"hex"`
+	Upper  string     `env:"TEST_REGISTRY_UPPER"`
+	Triple [3]int     `env:"TEST_REGISTRY_TRIPLE"`
+	Level  dummyLevel `env:"TEST_REGISTRY_LEVEL"`
+}
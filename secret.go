@@ -0,0 +1,121 @@
+package dotenv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// redacted replaces secret values wherever they would otherwise be printed.
+const redacted = "******"
+
+var secretType = reflect.TypeOf(Secret(""))
+
+// Secret is a string which is never printed: formatting it with the fmt
+// package, marshaling it to JSON or logging it with log/slog always produces
+// `******`. The actual value is only available through Reveal.
+//
+// Secret fields are parsed as any other string, including within slices and
+// maps, and their raw values are masked in the errors returned by Parse. The
+// `secret` env option provides the same masking to fields of any other type:
+//
+//	type Config struct {
+//		Password dotenv.Secret `env:"DB_PASSWORD"`
+//		APIKey   []byte        `env:"API_KEY,secret"`
+//	}
+type Secret string
+
+// Reveal returns the actual value of the secret.
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// String implements fmt.Stringer.
+func (s Secret) String() string {
+	return redacted
+}
+
+// GoString implements fmt.GoStringer.
+func (s Secret) GoString() string {
+	return fmt.Sprintf("%q", redacted)
+}
+
+// Format implements fmt.Formatter, so that no verb reveals the secret.
+func (s Secret) Format(f fmt.State, verb rune) {
+	if verb == 'q' || (verb == 'v' && f.Flag('#')) {
+		_, _ = io.WriteString(f, s.GoString())
+
+		return
+	}
+
+	_, _ = io.WriteString(f, redacted)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(redacted)
+}
+
+// LogValue implements slog.LogValuer.
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// isSecret reports whether the values of a field of the given type, with the
+// given env options, must be masked.
+func isSecret(typ reflect.Type, envOptions []string) bool {
+	for i := range envOptions {
+		if envOptions[i] == "secret" {
+			return true
+		}
+	}
+
+	for {
+		if typ == secretType {
+			return true
+		}
+
+		switch typ.Kind() {
+		case reflect.Map:
+			if typ.Key() == secretType {
+				return true
+			}
+
+			typ = typ.Elem()
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			typ = typ.Elem()
+		default:
+			return false
+		}
+	}
+}
+
+// mask returns the given raw value, or a placeholder when parsing a secret
+// field.
+func (o *options) mask(raw string) string {
+	if o.secret {
+		return redacted
+	}
+
+	return raw
+}
+
+// redactedError masks every occurrence of a raw value in the message of the
+// error it wraps.
+type redactedError struct {
+	err error
+	raw string
+}
+
+// Error implements the error interface.
+func (e *redactedError) Error() string {
+	return strings.ReplaceAll(e.err.Error(), e.raw, redacted)
+}
+
+// Unwrap returns the wrapped error.
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
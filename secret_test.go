@@ -0,0 +1,98 @@
+package dotenv_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tangelo-labs/go-dotenv"
+)
+
+func TestSecret(t *testing.T) {
+	t.Run("GIVEN a struct holding a secret", func(t *testing.T) {
+		cfg := dummySecrets{User: "admin", Password: "s3cr3t"}
+
+		t.Run("WHEN formatting it THEN the secret is never printed", func(t *testing.T) {
+			for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x"} {
+				out := fmt.Sprintf(format, cfg)
+
+				require.NotContains(t, out, "s3cr3t", format)
+				require.Contains(t, out, "******", format)
+			}
+
+			require.Equal(t, "******", cfg.Password.String())
+			require.Equal(t, `"******"`, cfg.Password.GoString())
+		})
+
+		t.Run("WHEN marshaling it to JSON THEN the secret is masked", func(t *testing.T) {
+			out, err := json.Marshal(cfg)
+			require.NoError(t, err)
+
+			require.JSONEq(t, `{"User":"admin","Password":"******","Tokens":null}`, string(out))
+		})
+
+		t.Run("WHEN logging it THEN the secret is masked", func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			slog.New(slog.NewTextHandler(buf, nil)).Info("boot", "password", cfg.Password)
+
+			require.NotContains(t, buf.String(), "s3cr3t")
+			require.Contains(t, buf.String(), "password=******")
+		})
+
+		t.Run("WHEN revealing it THEN the actual value is returned", func(t *testing.T) {
+			require.Equal(t, "s3cr3t", cfg.Password.Reveal())
+		})
+	})
+
+	t.Run("GIVEN secrets defined in the environment", func(t *testing.T) {
+		t.Setenv("TEST_SECRET_USER", "admin")
+		t.Setenv("TEST_SECRET_PASSWORD", "s3cr3t")
+		t.Setenv("TEST_SECRET_TOKENS", "a,b")
+
+		t.Run("WHEN parsing them THEN secret fields are populated", func(t *testing.T) {
+			var cfg dummySecrets
+
+			require.NoError(t, dotenv.Parse(&cfg))
+			require.Equal(t, "s3cr3t", cfg.Password.Reveal())
+			require.Equal(t, []dotenv.Secret{"a", "b"}, cfg.Tokens)
+		})
+	})
+
+	t.Run("GIVEN invalid values bound to secret fields", func(t *testing.T) {
+		t.Setenv("TEST_SECRET_PIN", "12a4")
+		t.Setenv("TEST_SECRET_KEYS", "k3y:1,k3y:2")
+		t.Setenv("TEST_SECRET_LEVEL", "t0ps3cr3t")
+
+		t.Run("WHEN parsing them THEN the values are masked in errors", func(t *testing.T) {
+			var cfg struct {
+				Pin   int                      `env:"TEST_SECRET_PIN,secret"`
+				Keys  map[dotenv.Secret]string `env:"TEST_SECRET_KEYS"`
+				Level string                   `env:"TEST_SECRET_LEVEL,secret" oneof:"low high"`
+			}
+
+			err := dotenv.Parse(&cfg)
+			require.Error(t, err)
+
+			require.True(t, errors.Is(err, dotenv.ErrInvalidValue))
+			require.True(t, errors.Is(err, dotenv.ErrValidation))
+			require.NotContains(t, err.Error(), "12a4")
+			require.NotContains(t, err.Error(), "k3y")
+			require.NotContains(t, err.Error(), "t0ps3cr3t")
+
+			var invalid *dotenv.InvalidValueError
+
+			require.True(t, errors.As(err, &invalid))
+			require.Equal(t, "******", invalid.Value)
+		})
+	})
+}
+
+type dummySecrets struct {
+	User     string          `env:"TEST_SECRET_USER"`
+	Password dotenv.Secret   `env:"TEST_SECRET_PASSWORD"`
+	Tokens   []dotenv.Secret `env:"TEST_SECRET_TOKENS"`
+}
@@ -0,0 +1,255 @@
+package dotenv
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Source provides the values of environment variables.
+type Source interface {
+	// Lookup returns the value of the given variable, and whether it is
+	// defined by this source.
+	Lookup(key string) (string, bool)
+}
+
+// EnumerableSource is implemented by sources able to list the variables they
+// define.
+type EnumerableSource interface {
+	Source
+
+	// Keys returns the names of every variable defined by this source.
+	Keys() []string
+}
+
+// SourceFunc adapts an ordinary function to the Source interface.
+type SourceFunc func(key string) (string, bool)
+
+// Lookup calls f(key).
+func (f SourceFunc) Lookup(key string) (string, bool) {
+	return f(key)
+}
+
+// envSource reads variables from the process environment.
+type envSource struct{}
+
+// mapSource reads variables from an in-memory map.
+type mapSource map[string]string
+
+// fileSource reads variables read from files by FileSource or DirSource.
+type fileSource struct {
+	mapSource
+
+	// locations holds the location of the definition of each variable.
+	locations map[string]location
+}
+
+// overrideSource reads variables from the WithOverride stack.
+type overrideSource struct{}
+
+// EnvSource returns a Source reading variables from the process environment.
+func EnvSource() Source {
+	return envSource{}
+}
+
+// MapSource returns a Source reading variables from a copy of the given map.
+func MapSource(values map[string]string) Source {
+	m := make(mapSource, len(values))
+
+	for k, v := range values {
+		m[k] = v
+	}
+
+	return m
+}
+
+// FileSource returns a Source reading variables from the given dotenv files.
+// Files are read immediately, later files take precedence over earlier ones,
+// and the `.env` file of the working directory is read when none is given.
+// The process environment is never modified.
+func FileSource(paths ...string) (Source, error) {
+	if len(paths) == 0 {
+		paths = []string{".env"}
+	}
+
+	values, locations, err := readDotEnvFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	return fileSource{mapSource: values, locations: locations}, nil
+}
+
+// DirSource returns a Source reading variables from the files of the given
+// directory, as mounted by Kubernetes for ConfigMap and Secret volumes: each
+// file name is a variable name, and its contents the value. Files are read
+// immediately, the process environment is never modified.
+//
+// Entries whose name starts with `..`, such as the `..data` symlink and the
+// timestamped directories created by Kubernetes, are ignored, as well as
+// subdirectories. Symlinks to files are followed. Trailing line breaks are
+// removed from values, and files larger than 1 MiB are rejected.
+//
+// Such source may be layered with any other, for instance:
+//
+//	config, err := dotenv.DirSource("/etc/config")
+//	if err != nil {
+//		return err
+//	}
+//
+//	// process environment wins over mounted files, which win over the .env file
+//	err = dotenv.ParseFrom(&cfg, dotenv.OverrideSource(), dotenv.EnvSource(), config, dotenvFile)
+func DirSource(dir string) (Source, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(mapSource, len(entries))
+	locations := make(map[string]location, len(entries))
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, "..") {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, &FileError{Var: name, Path: path, Err: err}
+		}
+
+		if info.IsDir() {
+			continue
+		}
+
+		content, err := readLimited(path, defaultMaxFileSize)
+		if err != nil {
+			return nil, &FileError{Var: name, Path: path, Err: err}
+		}
+
+		values[name] = trimLineBreaks(content)
+		locations[name] = location{path: path}
+	}
+
+	return fileSource{mapSource: values, locations: locations}, nil
+}
+
+// MemorySource returns a Source reading variables loaded by the default Parser
+// using the LoadModeInMemory mode.
+func MemorySource() Source {
+	return memoryEnv
+}
+
+// OverrideSource returns a Source reading variables overridden by the
+// enclosing WithOverride calls, or by OverrideT for the current test, if any.
+func OverrideSource() Source {
+	return overrideSource{}
+}
+
+// DefaultSources returns the chain of sources used by Parse when no
+// WithSources option is given: OverrideSource, EnvSource and MemorySource.
+func DefaultSources() []Source {
+	return defaultParser.opts.defaultSources()
+}
+
+// WithSources sets the ordered chain of sources used to resolve variables.
+// The first source defining a variable wins, and an empty chain resolves no
+// variable at all.
+//
+// Note that overrides set by WithOverride are only honoured if OverrideSource
+// is part of the chain.
+func WithSources(sources ...Source) Option {
+	return func(o *options) {
+		// a non-nil chain tells an empty one apart from the default one
+		o.sources = append([]Source{}, sources...)
+	}
+}
+
+// ParseFrom injects variables resolved through the given ordered chain of
+// sources into the given struct, without touching the process environment.
+// The first source defining a variable wins, and no variable is resolved when
+// no source is given.
+//
+// See Parse function for more information.
+func ParseFrom(st interface{}, sources ...Source) error {
+	return Parse(st, WithSources(sources...))
+}
+
+// Lookup implements the Source interface.
+func (envSource) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+// Keys implements the EnumerableSource interface.
+func (envSource) Keys() []string {
+	environ := os.Environ()
+	keys := make([]string, 0, len(environ))
+
+	for _, kv := range environ {
+		if i := strings.Index(kv, "="); i > 0 {
+			keys = append(keys, kv[:i])
+		}
+	}
+
+	return keys
+}
+
+// Lookup implements the Source interface.
+func (m mapSource) Lookup(key string) (string, bool) {
+	v, ok := m[key]
+
+	return v, ok
+}
+
+// Keys implements the EnumerableSource interface.
+func (m mapSource) Keys() []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Lookup implements the Source interface.
+func (overrideSource) Lookup(key string) (string, bool) {
+	if tuples, overridden := isOverriddenCall(); overridden {
+		if v, ok := tuples[key]; ok {
+			return v, true
+		}
+	}
+
+	return lookupGoroutineOverride(key)
+}
+
+// lookupSources resolves the given variable through the given chain of
+// sources. The resolution stops at the first source defining the variable as
+// Unset, in which case it is reported as undefined.
+func lookupSources(sources []Source, key string) (string, bool) {
+	v, _, ok := findSource(sources, key)
+
+	return v, ok
+}
+
+// findSource is like lookupSources, but also returns the source defining the
+// given variable.
+func findSource(sources []Source, key string) (string, Source, bool) {
+	for _, s := range sources {
+		if v, ok := s.Lookup(key); ok {
+			if v == Unset {
+				return "", nil, false
+			}
+
+			return v, s, true
+		}
+	}
+
+	return "", nil, false
+}
@@ -0,0 +1,135 @@
+package dotenv_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tangelo-labs/go-dotenv"
+)
+
+func TestParseFrom(t *testing.T) {
+	t.Run("GIVEN a chain of map and file sources AND a variable defined in the process environment", func(t *testing.T) {
+		dir := writeTemp(t, map[string]string{
+			".env": "TEST_SOURCE_A=file\nTEST_SOURCE_B=file\nTEST_SOURCE_C=file\n",
+		})
+
+		file, err := dotenv.FileSource(filepath.Join(dir, ".env"))
+		require.NoError(t, err)
+
+		t.Setenv("TEST_SOURCE_D", "process")
+
+		t.Run("WHEN parsing from such chain THEN earlier sources win AND the process environment is ignored", func(t *testing.T) {
+			env := dummySources{}
+			err := dotenv.ParseFrom(&env,
+				dotenv.MapSource(map[string]string{"TEST_SOURCE_A": "map"}),
+				dotenv.SourceFunc(func(key string) (string, bool) {
+					if key == "TEST_SOURCE_B" {
+						return "func", true
+					}
+
+					return "", false
+				}),
+				file,
+			)
+
+			require.NoError(t, err)
+			require.Equal(t, "map", env.A)
+			require.Equal(t, "func", env.B)
+			require.Equal(t, "file", env.C)
+			require.Equal(t, "default", env.D)
+		})
+
+		t.Run("WHEN parsing from an empty chain THEN no variable is resolved", func(t *testing.T) {
+			env := dummySources{}
+
+			require.NoError(t, dotenv.ParseFrom(&env))
+			require.Equal(t, "default", env.D)
+		})
+
+		t.Run("WHEN parsing from a chain including the process environment THEN process variables are seen", func(t *testing.T) {
+			env := dummySources{}
+
+			require.NoError(t, dotenv.ParseFrom(&env, dotenv.EnvSource(), file))
+			require.Equal(t, "file", env.A)
+			require.Equal(t, "process", env.D)
+		})
+
+		t.Run("WHEN parsing from a chain including overrides within WithOverride THEN overrides are seen", func(t *testing.T) {
+			dotenv.WithOverride(func() {
+				env := dummySources{}
+
+				require.NoError(t, dotenv.ParseFrom(&env, dotenv.OverrideSource(), file))
+				require.Equal(t, "override", env.A)
+				require.Equal(t, "file", env.B)
+			}, "TEST_SOURCE_A", "override")
+		})
+	})
+
+	t.Run("GIVEN an enumerable map source", func(t *testing.T) {
+		src := dotenv.MapSource(map[string]string{"B": "2", "A": "1"})
+
+		t.Run("WHEN listing its keys THEN every defined variable is returned", func(t *testing.T) {
+			enumerable, ok := src.(dotenv.EnumerableSource)
+
+			require.True(t, ok)
+			require.Equal(t, []string{"A", "B"}, enumerable.Keys())
+		})
+	})
+}
+
+type dummySources struct {
+	A string `env:"TEST_SOURCE_A"`
+	B string `env:"TEST_SOURCE_B"`
+	C string `env:"TEST_SOURCE_C"`
+	D string `env:"TEST_SOURCE_D" default:"default"`
+}
+
+func TestDirSource(t *testing.T) {
+	t.Run("GIVEN a directory laid out as a Kubernetes ConfigMap volume", func(t *testing.T) {
+		dir := writeTemp(t, map[string]string{
+			"..2024_01_01_00_00_00.000000000/TEST_DIR_HOST": "db.local\n",
+			"..2024_01_01_00_00_00.000000000/TEST_DIR_PORT": "5432",
+			"nested/TEST_DIR_IGNORED":                       "ignored",
+		})
+
+		require.NoError(t, os.Symlink("..2024_01_01_00_00_00.000000000", filepath.Join(dir, "..data")))
+		require.NoError(t, os.Symlink(filepath.Join("..data", "TEST_DIR_HOST"), filepath.Join(dir, "TEST_DIR_HOST")))
+		require.NoError(t, os.Symlink(filepath.Join("..data", "TEST_DIR_PORT"), filepath.Join(dir, "TEST_DIR_PORT")))
+
+		t.Run("WHEN reading it as a source THEN one variable per key file is defined", func(t *testing.T) {
+			src, err := dotenv.DirSource(dir)
+			require.NoError(t, err)
+
+			enumerable, ok := src.(dotenv.EnumerableSource)
+
+			require.True(t, ok)
+			require.Equal(t, []string{"TEST_DIR_HOST", "TEST_DIR_PORT"}, enumerable.Keys())
+		})
+
+		t.Run("WHEN layering it below the process environment THEN the process environment wins", func(t *testing.T) {
+			t.Setenv("TEST_DIR_PORT", "6543")
+
+			src, err := dotenv.DirSource(dir)
+			require.NoError(t, err)
+
+			var env struct {
+				Host string `env:"TEST_DIR_HOST"`
+				Port int    `env:"TEST_DIR_PORT"`
+			}
+
+			require.NoError(t, dotenv.ParseFrom(&env, dotenv.EnvSource(), src))
+			require.Equal(t, "db.local", env.Host)
+			require.Equal(t, 6543, env.Port)
+		})
+	})
+
+	t.Run("GIVEN a directory that does not exist", func(t *testing.T) {
+		t.Run("WHEN reading it as a source THEN an error is returned", func(t *testing.T) {
+			_, err := dotenv.DirSource(filepath.Join(t.TempDir(), "missing"))
+
+			require.Error(t, err)
+		})
+	})
+}
@@ -0,0 +1,447 @@
+package dotenv
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/structtag"
+	"github.com/xhit/go-str2duration"
+)
+
+// Validator is implemented by structs checking their own values, such as
+// cross-field rules like "TLS_CERT requires TLS_KEY".
+//
+// Parse calls Validate on the given struct, and on every nested struct, once
+// all of their fields have been injected, defaulted and checked against
+// validation tags. Nested structs are visited first. Returned errors are
+// reported along with the path of the struct, and match ErrValidation. As for
+// Defaulter, the method of an embedded struct is only called once, through
+// the embedding struct it is promoted to.
+type Validator interface {
+	Validate() error
+}
+
+// validatorType is the type of the Validator interface.
+var validatorType = reflect.TypeOf((*Validator)(nil)).Elem()
+
+// validatorError wraps an error returned by a Validator.
+type validatorError struct {
+	err error
+}
+
+// Error implements the error interface.
+func (e *validatorError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrValidation, e.err)
+}
+
+// Is reports whether the target is ErrValidation.
+func (e *validatorError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Unwrap returns the error returned by the Validator.
+func (e *validatorError) Unwrap() error {
+	return e.err
+}
+
+// validator checks a single field value against the argument of a
+// validation tag.
+type validator func(v reflect.Value, arg string) error
+
+// checkValidators are the validators available through the `validate` tag.
+var checkValidators = map[string]func(s string) error{
+	"url":      validateURL,
+	"hostport": validateHostPort,
+	"file":     validateFile,
+	"dir":      validateDir,
+}
+
+// validateStruct checks every tagged field of the given, already injected,
+// struct value against its validation tags, and then calls Validate on each
+// struct implementing the Validator interface. Fields whose path is present
+// in the given set are skipped, as they already failed to be injected, and so
+// are the Validate methods of the structs holding them.
+func validateStruct(val reflect.Value, o *options, skip map[string]bool) ValidationErrors {
+	errs := walkStruct(val, "", "", o,
+		func(field reflect.Value, path, varName string, envOptions []string, tags *structtag.Tags) *FieldError {
+			if skip[path] {
+				return nil
+			}
+
+			if err := validateField(field, varName, tags, o.defined[path], isSecret(field.Type(), envOptions)); err != nil {
+				p := provenanceOf(field, varName, tags, o)
+
+				return &FieldError{Field: path, Var: varName, Provenance: &p, Err: err}
+			}
+
+			return nil
+		},
+		func(val reflect.Value, path string, parent reflect.Type) *FieldError {
+			for failed := range skip {
+				if strings.HasPrefix(failed, path) {
+					return nil
+				}
+			}
+
+			if err := callValidator(val, parent); err != nil {
+				return &FieldError{Field: strings.TrimSuffix(path, "."), Err: &validatorError{err: err}}
+			}
+
+			return nil
+		},
+	)
+
+	var out ValidationErrors
+
+	for i := range errs {
+		if !skip[errs[i].Field] {
+			out = append(out, errs[i])
+		}
+	}
+
+	return out
+}
+
+// callValidator calls Validate on the given struct value if it implements the
+// Validator interface, unless the method is promoted to the given struct type
+// embedding it.
+func callValidator(val reflect.Value, parent reflect.Type) error {
+	if !val.CanAddr() || !val.Addr().CanInterface() {
+		return nil
+	}
+
+	if parent != nil && reflect.PointerTo(parent).Implements(validatorType) {
+		return nil
+	}
+
+	if v, ok := val.Addr().Interface().(Validator); ok {
+		return v.Validate()
+	}
+
+	return nil
+}
+
+// validateField checks the given field value against the validation tags
+// found in the given tags. Zero values are only checked against the `min`,
+// `max` and `oneof` tags when their variable is defined, and never otherwise.
+// The values of secret fields are not reported.
+func validateField(field reflect.Value, varName string, tags *structtag.Tags, defined, secret bool) error {
+	v := field
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	zero := v.IsZero()
+	if zero && !defined {
+		return nil
+	}
+
+	rules := []struct {
+		tag   string
+		check validator
+		zero  bool
+	}{
+		{tag: "min", check: validateMin, zero: true},
+		{tag: "max", check: validateMax, zero: true},
+		{tag: "len", check: validateLen},
+		{tag: "oneof", check: eachElement(validateOneOf), zero: true},
+		{tag: "regex", check: eachElement(validateRegex)},
+		{tag: "validate", check: eachElement(validateChecks)},
+	}
+
+	for _, rule := range rules {
+		if zero && !rule.zero {
+			continue
+		}
+
+		tag, err := tags.Get(rule.tag)
+		if err != nil {
+			continue
+		}
+
+		if err := rule.check(v, tag.Value()); err != nil {
+			if secret {
+				return fmt.Errorf("%w: environment variable `%s` does not satisfy the `%s` tag", ErrValidation, varName, rule.tag)
+			}
+
+			return fmt.Errorf("%w: environment variable `%s` %s", ErrValidation, varName, err)
+		}
+	}
+
+	return nil
+}
+
+// validateMin checks that the given number or duration is not lower than the
+// given limit, or that the given string, slice or map has at least as many
+// elements as the given limit.
+func validateMin(v reflect.Value, arg string) error {
+	cmp, err := compareLimit(v, arg)
+	if err != nil {
+		return err
+	}
+
+	if cmp < 0 {
+		return fmt.Errorf("must be at least %s", arg)
+	}
+
+	return nil
+}
+
+// validateMax checks that the given number or duration is not greater than
+// the given limit, or that the given string, slice or map has at most as many
+// elements as the given limit.
+func validateMax(v reflect.Value, arg string) error {
+	cmp, err := compareLimit(v, arg)
+	if err != nil {
+		return err
+	}
+
+	if cmp > 0 {
+		return fmt.Errorf("must be at most %s", arg)
+	}
+
+	return nil
+}
+
+// validateLen checks that the given string, slice or map has exactly the
+// given number of elements.
+func validateLen(v reflect.Value, arg string) error {
+	n, err := strconv.Atoi(arg)
+	if err != nil {
+		return fmt.Errorf("has an invalid `len` tag `%s`", arg)
+	}
+
+	if !hasLen(v) {
+		return fmt.Errorf("of type `%s` does not support the `len` tag", v.Type())
+	}
+
+	if v.Len() != n {
+		return fmt.Errorf("must have a length of %d, got %d", n, v.Len())
+	}
+
+	return nil
+}
+
+// validateOneOf checks that the given value is one of the space separated
+// values of the given argument.
+func validateOneOf(v reflect.Value, arg string) error {
+	s := stringOf(v)
+	allowed := strings.Fields(arg)
+
+	for i := range allowed {
+		if allowed[i] == s {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("must be one of [%s], got `%s`", strings.Join(allowed, ", "), s)
+}
+
+// validateRegex checks that the given value matches the given regular
+// expression.
+func validateRegex(v reflect.Value, arg string) error {
+	re, err := regexp.Compile(arg)
+	if err != nil {
+		return fmt.Errorf("has an invalid `regex` tag: %s", err)
+	}
+
+	if s := stringOf(v); !re.MatchString(s) {
+		return fmt.Errorf("must match `%s`, got `%s`", arg, s)
+	}
+
+	return nil
+}
+
+// validateChecks runs every check listed in the given comma separated
+// argument against the given value.
+func validateChecks(v reflect.Value, arg string) error {
+	for _, name := range strings.Split(arg, ",") {
+		check, ok := checkValidators[strings.TrimSpace(name)]
+		if !ok {
+			return fmt.Errorf("has an unknown validation `%s`", name)
+		}
+
+		if err := check(stringOf(v)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateURL checks that the given string is an absolute URL.
+func validateURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("must be an absolute URL, got `%s`", s)
+	}
+
+	return nil
+}
+
+// validateHostPort checks that the given string is a `host:port` address.
+func validateHostPort(s string) error {
+	_, port, err := net.SplitHostPort(s)
+	if err != nil {
+		return fmt.Errorf("must be a `host:port` address, got `%s`", s)
+	}
+
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return fmt.Errorf("must have a valid port, got `%s`", port)
+	}
+
+	return nil
+}
+
+// validateFile checks that the given string is the path of an existing file.
+func validateFile(s string) error {
+	info, err := os.Stat(s)
+	if err != nil || info.IsDir() {
+		return fmt.Errorf("must be the path of an existing file, got `%s`", s)
+	}
+
+	return nil
+}
+
+// validateDir checks that the given string is the path of an existing
+// directory.
+func validateDir(s string) error {
+	info, err := os.Stat(s)
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("must be the path of an existing directory, got `%s`", s)
+	}
+
+	return nil
+}
+
+// eachElement applies the given validator to every element of slices and
+// arrays, or to the value itself otherwise.
+func eachElement(check validator) validator {
+	return func(v reflect.Value, arg string) error {
+		if !isCollection(v) {
+			return check(v, arg)
+		}
+
+		for i := 0; i < v.Len(); i++ {
+			if err := check(v.Index(i), arg); err != nil {
+				return fmt.Errorf("element %d %s", i, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// compareLimit compares the given value, or its length, with the given limit,
+// returning -1, 0 or 1 if it is lower, equal or greater respectively.
+func compareLimit(v reflect.Value, arg string) (int, error) {
+	invalid := fmt.Errorf("has an invalid limit `%s` for type `%s`", arg, v.Type())
+
+	switch {
+	case v.Type() == durationType:
+		d, err := str2duration.Str2Duration(arg)
+		if err != nil {
+			return 0, invalid
+		}
+
+		return compare(v.Int(), int64(d)), nil
+	case hasLen(v):
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return 0, invalid
+		}
+
+		return compare(v.Len(), n), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil {
+			return 0, invalid
+		}
+
+		return compare(v.Int(), n), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return 0, invalid
+		}
+
+		return compare(v.Uint(), n), nil
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return 0, invalid
+		}
+
+		return compare(v.Float(), n), nil
+	}
+
+	return 0, fmt.Errorf("of type `%s` does not support limits", v.Type())
+}
+
+// compare returns -1, 0 or 1 if a is lower, equal or greater than b.
+func compare[T cmp.Ordered](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+// hasLen reports whether limits apply to the length of the given value.
+func hasLen(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+
+	return false
+}
+
+// isCollection reports whether the given value is a slice or array whose
+// elements are validated individually.
+func isCollection(v reflect.Value) bool {
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return false
+	}
+
+	// byte slices such as net.IP are validated as a whole
+	return v.Type().Elem().Kind() != reflect.Uint8
+}
+
+// stringOf returns the string representation of the given value, honouring
+// fmt.Stringer implementations on both value and pointer receivers.
+func stringOf(v reflect.Value) string {
+	if v.Kind() == reflect.String {
+		return v.String()
+	}
+
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+
+	if v.CanAddr() {
+		if s, ok := v.Addr().Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+	}
+
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,224 @@
+package dotenv_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tangelo-labs/go-dotenv"
+)
+
+func TestParse_Validation(t *testing.T) {
+	t.Run("GIVEN a struct with validation tags AND valid values", func(t *testing.T) {
+		dir := writeTemp(t, map[string]string{"cert.pem": "cert"})
+		src := dotenv.MapSource(map[string]string{
+			"PORT":     "8080",
+			"TIMEOUT":  "30s",
+			"LEVEL":    "info",
+			"NAME":     "api",
+			"CODE":     "abc",
+			"ENDPOINT": "https://example.com/api",
+			"ADDR":     "localhost:6379",
+			"CERT":     dir + "/cert.pem",
+			"DATA":     dir,
+			"TAGS":     "a,b",
+			"BIG":      "9007199254740992",
+			"HUGE":     "18446744073709551614",
+		})
+
+		t.Run("WHEN parsing THEN no error is raised", func(t *testing.T) {
+			env := dummyValidation{}
+
+			require.NoError(t, dotenv.ParseFrom(&env, src))
+			require.Equal(t, 30*time.Second, env.Timeout)
+		})
+	})
+
+	t.Run("GIVEN a struct with validation tags AND no value defined", func(t *testing.T) {
+		t.Run("WHEN parsing THEN zero values are not validated", func(t *testing.T) {
+			env := dummyValidation{}
+
+			require.NoError(t, dotenv.ParseFrom(&env))
+		})
+	})
+
+	t.Run("GIVEN a struct with validation tags AND zero values defined", func(t *testing.T) {
+		t.Run("WHEN parsing THEN tags other than min, max and oneof are not checked", func(t *testing.T) {
+			env := dummyValidation{}
+			src := dotenv.MapSource(map[string]string{"CODE": "", "ENDPOINT": "", "CERT": ""})
+
+			require.NoError(t, dotenv.ParseFrom(&env, src))
+		})
+	})
+
+	tests := []struct {
+		name  string
+		vars  map[string]string
+		field string
+	}{
+		{name: "a number lower than min", vars: map[string]string{"PORT": "80"}, field: "Port"},
+		{name: "a number greater than max", vars: map[string]string{"PORT": "70000"}, field: "Port"},
+		{name: "a duration greater than max", vars: map[string]string{"TIMEOUT": "2m"}, field: "Timeout"},
+		{name: "a value not in oneof", vars: map[string]string{"LEVEL": "verbose"}, field: "Level"},
+		{name: "a string shorter than min", vars: map[string]string{"NAME": "a"}, field: "Name"},
+		{name: "a string not matching regex", vars: map[string]string{"NAME": "API"}, field: "Name"},
+		{name: "a string with unexpected len", vars: map[string]string{"CODE": "abcd"}, field: "Code"},
+		{name: "a relative URL", vars: map[string]string{"ENDPOINT": "/api"}, field: "Endpoint"},
+		{name: "an address without port", vars: map[string]string{"ADDR": "localhost"}, field: "Addr"},
+		{name: "a missing file", vars: map[string]string{"CERT": "/does/not/exist.pem"}, field: "Cert"},
+		{name: "a missing directory", vars: map[string]string{"DATA": "/does/not/exist"}, field: "Data"},
+		{name: "a slice with an element not in oneof", vars: map[string]string{"TAGS": "a,z"}, field: "Tags"},
+		{name: "a slice with too many elements", vars: map[string]string{"TAGS": "a,b,c,a"}, field: "Tags"},
+		{name: "an int64 greater than a max beyond float precision", vars: map[string]string{"BIG": "9007199254740993"}, field: "Big"},
+		{name: "a uint64 greater than a max beyond float precision", vars: map[string]string{"HUGE": "18446744073709551615"}, field: "Huge"},
+		{name: "a zero number defined below min", vars: map[string]string{"PORT": "0"}, field: "Port"},
+		{name: "an empty string defined not in oneof", vars: map[string]string{"LEVEL": ""}, field: "Level"},
+	}
+
+	for _, test := range tests {
+		t.Run("GIVEN a struct with validation tags AND "+test.name, func(t *testing.T) {
+			t.Run("WHEN parsing THEN a validation error is raised for the field", func(t *testing.T) {
+				env := dummyValidation{}
+				err := dotenv.ParseFrom(&env, dotenv.MapSource(test.vars))
+
+				require.ErrorIs(t, err, dotenv.ErrValidation)
+
+				var verrs dotenv.ValidationErrors
+
+				require.ErrorAs(t, err, &verrs)
+				require.Len(t, verrs, 1)
+				require.Equal(t, test.field, verrs[0].Field)
+			})
+		})
+	}
+
+	t.Run("GIVEN a struct with validation tags AND a value that cannot be converted", func(t *testing.T) {
+		t.Run("WHEN parsing THEN only the conversion error is reported", func(t *testing.T) {
+			env := dummyValidation{}
+			err := dotenv.ParseFrom(&env, dotenv.MapSource(map[string]string{"PORT": "abc"}))
+
+			var verrs dotenv.ValidationErrors
+
+			require.ErrorAs(t, err, &verrs)
+			require.Len(t, verrs, 1)
+			require.ErrorIs(t, err, dotenv.ErrInvalidValue)
+		})
+	})
+}
+
+type dummyValidation struct {
+	Port     int           `env:"PORT" min:"1024" max:"65535"`
+	Timeout  time.Duration `env:"TIMEOUT" min:"1s" max:"1m"`
+	Level    string        `env:"LEVEL" oneof:"debug info warn error"`
+	Name     string        `env:"NAME" min:"2" regex:"^[a-z]+$"`
+	Code     string        `env:"CODE" len:"3"`
+	Endpoint string        `env:"ENDPOINT" validate:"url"`
+	Addr     string        `env:"ADDR" validate:"hostport"`
+	Cert     string        `env:"CERT" validate:"file"`
+	Data     string        `env:"DATA" validate:"dir"`
+	Tags     []string      `env:"TAGS" oneof:"a b c" max:"3"`
+	Big      int64         `env:"BIG" max:"9007199254740992"`
+	Huge     uint64        `env:"HUGE" max:"18446744073709551614"`
+}
+
+func TestParse_Validator(t *testing.T) {
+	t.Run("GIVEN a struct implementing Validator with a nested struct implementing Validator", func(t *testing.T) {
+		t.Run("WHEN parsing valid values THEN no error is raised", func(t *testing.T) {
+			env := dummyValidator{}
+			src := dotenv.MapSource(map[string]string{"TLS_CERT": "cert", "TLS_KEY": "key", "NAME": "api"})
+
+			require.NoError(t, dotenv.ParseFrom(&env, src))
+		})
+
+		t.Run("WHEN parsing values failing both validators THEN both errors are reported with their struct path", func(t *testing.T) {
+			env := dummyValidator{}
+			src := dotenv.MapSource(map[string]string{"TLS_CERT": "cert"})
+			err := dotenv.ParseFrom(&env, src)
+
+			require.ErrorIs(t, err, dotenv.ErrValidation)
+			require.ErrorIs(t, err, errTLSKeyRequired)
+			require.ErrorIs(t, err, errNameRequired)
+
+			var verrs dotenv.ValidationErrors
+
+			require.ErrorAs(t, err, &verrs)
+			require.Len(t, verrs, 2)
+			require.Equal(t, "TLS", verrs[0].Field)
+			require.Equal(t, "", verrs[1].Field)
+			require.True(t, strings.HasPrefix(err.Error(), "2 configuration error(s):\n"))
+		})
+
+		t.Run("WHEN parsing a nested value that cannot be converted THEN the nested validator is skipped", func(t *testing.T) {
+			env := dummyValidator{}
+			src := dotenv.MapSource(map[string]string{"TLS_CERT": "cert", "TLS_PORT": "abc", "NAME": "api"})
+			err := dotenv.ParseFrom(&env, src)
+
+			var verrs dotenv.ValidationErrors
+
+			require.ErrorAs(t, err, &verrs)
+			require.Len(t, verrs, 1)
+			require.ErrorIs(t, err, dotenv.ErrInvalidValue)
+		})
+
+		t.Run("WHEN using MustParse with invalid values THEN it panics", func(t *testing.T) {
+			env := dummyValidator{}
+
+			require.Panics(t, func() {
+				dotenv.MustParse(&env, dotenv.WithSources(dotenv.MapSource(map[string]string{"TLS_CERT": "cert"})))
+			})
+		})
+	})
+
+	t.Run("GIVEN a struct embedding a Validator implementation", func(t *testing.T) {
+		t.Run("WHEN parsing invalid values THEN the promoted Validate is called and reported once", func(t *testing.T) {
+			env := struct {
+				dummyTLS
+			}{}
+
+			src := dotenv.MapSource(map[string]string{"CERT": "cert"})
+			err := dotenv.ParseFrom(&env, src)
+
+			require.ErrorIs(t, err, errTLSKeyRequired)
+
+			var verrs dotenv.ValidationErrors
+
+			require.ErrorAs(t, err, &verrs)
+			require.Len(t, verrs, 1)
+			require.Equal(t, "", verrs[0].Field)
+		})
+	})
+}
+
+var (
+	errTLSKeyRequired = errors.New("TLS_CERT requires TLS_KEY")
+	errNameRequired   = errors.New("name is required")
+)
+
+type dummyTLS struct {
+	Cert string `env:"CERT"`
+	Key  string `env:"KEY"`
+	Port int    `env:"PORT"`
+}
+
+func (d *dummyTLS) Validate() error {
+	if d.Cert != "" && d.Key == "" {
+		return errTLSKeyRequired
+	}
+
+	return nil
+}
+
+type dummyValidator struct {
+	Name string   `env:"NAME"`
+	TLS  dummyTLS `envPrefix:"TLS_"`
+}
+
+func (d *dummyValidator) Validate() error {
+	if d.Name == "" {
+		return errNameRequired
+	}
+
+	return nil
+}
@@ -17,123 +17,123 @@ func (v value) IsZero() bool {
 }
 
 // AsInt cast this value to int type.
-func (v value) AsInt() int {
+func (v value) AsInt() (int, error) {
 	i, err := strconv.Atoi(string(v))
 	if err != nil {
-		return 0
+		return 0, err
 	}
 
-	return i
+	return i, nil
 }
 
 // AsInt8 cast this value to int8 type.
-func (v value) AsInt8() int8 {
+func (v value) AsInt8() (int8, error) {
 	i, err := strconv.ParseInt(string(v), 10, 8)
 	if err != nil {
-		return 0
+		return 0, err
 	}
 
-	return int8(i)
+	return int8(i), nil
 }
 
 // AsInt16 cast this value to int16 type.
-func (v value) AsInt16() int16 {
+func (v value) AsInt16() (int16, error) {
 	i, err := strconv.ParseInt(string(v), 10, 16)
 	if err != nil {
-		return 0
+		return 0, err
 	}
 
-	return int16(i)
+	return int16(i), nil
 }
 
 // AsInt32 cast this value to int32 type.
-func (v value) AsInt32() int32 {
+func (v value) AsInt32() (int32, error) {
 	i, err := strconv.ParseInt(string(v), 10, 32)
 	if err != nil {
-		return 0
+		return 0, err
 	}
 
-	return int32(i)
+	return int32(i), nil
 }
 
 // AsInt64 cast this value to int64 type.
-func (v value) AsInt64() int64 {
+func (v value) AsInt64() (int64, error) {
 	i, err := strconv.ParseInt(string(v), 10, 64)
 	if err != nil {
-		return 0
+		return 0, err
 	}
 
-	return i
+	return i, nil
 }
 
 // AsUint cast this value to uint type.
-func (v value) AsUint() uint {
+func (v value) AsUint() (uint, error) {
 	i, err := strconv.ParseUint(string(v), 10, 64)
 	if err != nil {
-		return 0
+		return 0, err
 	}
 
-	return uint(i)
+	return uint(i), nil
 }
 
 // AsUint8 cast this value to uint8 type.
-func (v value) AsUint8() uint8 {
+func (v value) AsUint8() (uint8, error) {
 	i, err := strconv.ParseUint(string(v), 10, 8)
 	if err != nil {
-		return 0
+		return 0, err
 	}
 
-	return uint8(i)
+	return uint8(i), nil
 }
 
 // AsUint16 cast this value to uint16 type.
-func (v value) AsUint16() uint16 {
+func (v value) AsUint16() (uint16, error) {
 	i, err := strconv.ParseUint(string(v), 10, 16)
 	if err != nil {
-		return 0
+		return 0, err
 	}
 
-	return uint16(i)
+	return uint16(i), nil
 }
 
 // AsUint32 cast this value to uint32 type.
-func (v value) AsUint32() uint32 {
+func (v value) AsUint32() (uint32, error) {
 	i, err := strconv.ParseUint(string(v), 10, 32)
 	if err != nil {
-		return 0
+		return 0, err
 	}
 
-	return uint32(i)
+	return uint32(i), nil
 }
 
 // AsUint64 cast this value to uint64 type.
-func (v value) AsUint64() uint64 {
+func (v value) AsUint64() (uint64, error) {
 	i, err := strconv.ParseUint(string(v), 10, 64)
 	if err != nil {
-		return 0
+		return 0, err
 	}
 
-	return i
+	return i, nil
 }
 
 // AsFloat32 cast this value float32 type.
-func (v value) AsFloat32() float32 {
+func (v value) AsFloat32() (float32, error) {
 	f, err := strconv.ParseFloat(string(v), 32)
 	if err != nil {
-		return 0
+		return 0, err
 	}
 
-	return float32(f)
+	return float32(f), nil
 }
 
 // AsFloat64 cast this value to float64 type.
-func (v value) AsFloat64() float64 {
+func (v value) AsFloat64() (float64, error) {
 	f, err := strconv.ParseFloat(string(v), 64)
 	if err != nil {
-		return 0
+		return 0, err
 	}
 
-	return f
+	return f, nil
 }
 
 // AsString cast this value to string type.
@@ -142,34 +142,34 @@ func (v value) AsString() string {
 }
 
 // AsBool cast this value to bool type.
-func (v value) AsBool() bool {
+func (v value) AsBool() (bool, error) {
 	b, err := strconv.ParseBool(string(v))
 	if err != nil {
-		return false
+		return false, err
 	}
 
-	return b
+	return b, nil
 }
 
 // AsTime cast this value to time.Time type using the given format layout.
-func (v value) AsTime(layout string) time.Time {
+func (v value) AsTime(layout string) (time.Time, error) {
 	t, err := time.Parse(layout, string(v))
 	if err != nil {
-		return time.Time{}
+		return time.Time{}, err
 	}
 
-	return t
+	return t, nil
 }
 
 // AsDuration cast this value to time.Duration type using as input values in human-readable format, such as:
 // "30m", "1h30m", "2d", "1w2d12h30m5s", etc.
-func (v value) AsDuration() time.Duration {
+func (v value) AsDuration() (time.Duration, error) {
 	d, err := str2duration.Str2Duration(string(v))
 	if err != nil {
-		return 0
+		return 0, err
 	}
 
-	return d
+	return d, nil
 }
 
 // AsStringSlice cast this value to []string type.
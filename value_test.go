@@ -42,8 +42,72 @@ func TestValue_AsDuration(t *testing.T) {
 	for _, test := range tests {
 		t.Run(fmt.Sprintf("GIVEN %s raw duration WHEN parsed as duration THEN should return %s", test.raw, test.expected.String()), func(t *testing.T) {
 			v := value(test.raw)
+			d, err := v.AsDuration()
 
-			assert.Equal(t, test.expected, v.AsDuration())
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, d)
+		})
+	}
+}
+
+func TestValue_InvalidConversions(t *testing.T) {
+	tests := []struct {
+		name string
+		conv func(v value) error
+	}{
+		{
+			name: "int",
+			conv: func(v value) error {
+				_, err := v.AsInt()
+
+				return err
+			},
+		},
+		{
+			name: "uint8",
+			conv: func(v value) error {
+				_, err := v.AsUint8()
+
+				return err
+			},
+		},
+		{
+			name: "float64",
+			conv: func(v value) error {
+				_, err := v.AsFloat64()
+
+				return err
+			},
+		},
+		{
+			name: "bool",
+			conv: func(v value) error {
+				_, err := v.AsBool()
+
+				return err
+			},
+		},
+		{
+			name: "duration",
+			conv: func(v value) error {
+				_, err := v.AsDuration()
+
+				return err
+			},
+		},
+		{
+			name: "time",
+			conv: func(v value) error {
+				_, err := v.AsTime(time.RFC3339)
+
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("GIVEN an invalid raw value WHEN parsed as %s THEN an error is returned", test.name), func(t *testing.T) {
+			assert.Error(t, test.conv(value("80a")))
 		})
 	}
 }